@@ -0,0 +1,171 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// WithAutoOptions adds OPTIONS route with `Allow` header listing registered http methods to every controller path
+// without explicit OPTIONS route
+func WithAutoOptions() Option {
+	return func(o *options) {
+		o.autoOptions = true
+	}
+}
+
+// WithMethodNotAllowed adds 405 Method Not Allowed route with `Allow` header listing registered http methods
+// for every unregistered standard http method to every controller path; response is rendered by the error handler
+// of the registration with *HTTPError of 405 status
+func WithMethodNotAllowed() Option {
+	return func(o *options) {
+		o.methodNotAllowed = true
+	}
+}
+
+// allowRoutes returns synthesized OPTIONS and 405 routes of all the plan routes paths (including sub-controllers ones)
+// NOTE paths with `Action` methods have routes for all http methods already
+func (p *controllerPlan) allowRoutes(o *options) (routes []controllerRoute) {
+
+	if !o.autoOptions && !o.methodNotAllowed {
+		return nil
+	}
+
+	paths, allowed := p.pathMethods()
+
+	t := reflect.TypeOf(p.instance)
+
+	synthesize := func(method, path, name string, h gin.HandlerFunc) {
+
+		var chain handlersChain
+
+		// ATN! only registration middleware, e.g. CORS one, but never controller wrappers
+		for _, mw := range o.middleware {
+			chain.add(mw, nameOfFunction(mw))
+		}
+
+		chain.add(h, name)
+
+		routes = append(routes, controllerRoute{
+			method:      method,
+			path:        path,
+			name:        name,
+			controller:  t,
+			chain:       chain,
+			synthesized: true,
+		})
+	}
+
+	for _, path := range paths {
+
+		methods := allowed[path]
+
+		if o.autoOptions && !methods[http.MethodOptions] {
+			methods[http.MethodOptions] = true
+			synthesize(http.MethodOptions, path, "Options", optionsHandler(allowHeader(methods)))
+		}
+
+		if !o.methodNotAllowed {
+			continue
+		}
+
+		allow := allowHeader(methods)
+
+		for _, m := range anyHttpMethods {
+			if !methods[m] {
+				synthesize(m, path, "MethodNotAllowed", methodNotAllowedHandler(allow, o.errorHandler))
+			}
+		}
+	}
+
+	return routes
+}
+
+// pathMethods returns all the plan routes paths in the registration order and the sets of their http methods
+// NOTE `Action` method route has all http methods
+func (p *controllerPlan) pathMethods() (paths []string, methods map[string]map[string]bool) {
+
+	methods = make(map[string]map[string]bool)
+
+	for i := range p.routes {
+
+		r := &p.routes[i]
+
+		set, ok := methods[r.path]
+
+		if !ok {
+			set = make(map[string]bool)
+			methods[r.path], paths = set, append(paths, r.path)
+		}
+
+		if r.method != methodActionNotch {
+			set[r.method] = true
+			continue
+		}
+
+		for _, m := range anyHttpMethods {
+			set[m] = true
+		}
+	}
+
+	return paths, methods
+}
+
+// allowHeader returns `Allow` header value of the methods in the standard methods order followed by the others sorted
+func allowHeader(methods map[string]bool) string {
+
+	list := make([]string, 0, len(methods))
+
+	for _, m := range anyHttpMethods {
+		if methods[m] {
+			list = append(list, m)
+		}
+	}
+
+	standard := len(list)
+
+	for m := range methods {
+		if !containsString(anyHttpMethods[:], m) {
+			list = append(list, m)
+		}
+	}
+
+	sort.Strings(list[standard:])
+
+	return strings.Join(list, ", ")
+}
+
+func optionsHandler(allow string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Header("Allow", allow)
+		ctx.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+func methodNotAllowedHandler(allow string, onError ErrorHandlerFunc) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Header("Allow", allow)
+		onError(ctx, NewHTTPError(http.StatusMethodNotAllowed, nil))
+	}
+}
@@ -0,0 +1,89 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type ControllerAllow struct{}
+
+func (c *ControllerAllow) GetItem(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "get")
+}
+
+func (c *ControllerAllow) PostItem(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "post")
+}
+
+func (c *ControllerAllow) ActionAny(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "any "+ctx.Request.Method)
+}
+
+func testAllowMiddleware(ctx *gin.Context) {
+	ctx.Header("X-Middleware", "1")
+}
+
+var (
+	testControllerAllow1Cases = []struct {
+		method, path string
+		status       int
+		allow        string
+		contentType  string
+	}{
+		{http.MethodOptions, "/allow/item", http.StatusNoContent, "GET, POST, OPTIONS", ""},
+		{http.MethodDelete, "/allow/item", http.StatusMethodNotAllowed, "GET, POST, OPTIONS", ProblemContentType},
+		{http.MethodPut, "/allow/item", http.StatusMethodNotAllowed, "GET, POST, OPTIONS", ProblemContentType},
+		{http.MethodGet, "/allow/item", http.StatusOK, "", "text/plain; charset=utf-8"},
+		// Action handles all methods itself
+		{http.MethodOptions, "/allow/any", http.StatusOK, "", "text/plain; charset=utf-8"},
+	}
+)
+
+//
+// go test -count=1 -v -run TestRegisterControllerAllow1
+
+func TestRegisterControllerAllow1(t *testing.T) {
+
+	r := newRouter()
+
+	if err := AttachController(r, &ControllerAllow{}, WithAutoOptions(), WithMethodNotAllowed(), WithMiddleware(testAllowMiddleware)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, c := range testControllerAllow1Cases {
+
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, httptest.NewRequest(c.method, c.path, nil))
+
+		if w.Code != c.status || w.Header().Get("Allow") != c.allow || w.Header().Get("Content-Type") != c.contentType {
+			t.Errorf("Request \"%s %s\" response mismatch: want < %d, Allow %q, Content-Type %q >, got < %d, Allow %q, Content-Type %q >",
+				c.method, c.path, c.status, c.allow, c.contentType, w.Code, w.Header().Get("Allow"), w.Header().Get("Content-Type"))
+		}
+
+		if w.Header().Get("X-Middleware") != "1" {
+			t.Errorf("Request \"%s %s\" skips registration middleware", c.method, c.path)
+		}
+	}
+}
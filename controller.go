@@ -0,0 +1,107 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+
+	"reflect"
+)
+
+type (
+	// Controller is the base type to embed into user controllers, it provides gin context of the current request
+	// and response helpers; controllers embedding it are always registered with per-request instances
+	// (shallow copy of the registered instance, if there is no factory) SEE WithPerRequest
+	Controller struct {
+		Ctx *gin.Context
+	}
+
+	// contextSetter is implemented by controllers embedding Controller
+	contextSetter interface {
+		SetContext(ctx *gin.Context)
+	}
+)
+
+var controllerType = reflect.TypeOf(Controller{})
+
+// embedsControllerByPointer reports whether Controller is promoted to the controller struct type st through
+// embedded pointer (`*Controller` or pointer to struct embedding it), which is shared by all shallow copies
+// of the registered instance and may be nil
+func embedsControllerByPointer(st reflect.Type) bool {
+
+	sf, ok := st.FieldByName(controllerType.Name())
+
+	if !ok || !sf.Anonymous {
+		return false
+	}
+
+	if sf.Type != controllerType && sf.Type != reflect.PtrTo(controllerType) {
+		return false
+	}
+
+	for _, i := range sf.Index {
+
+		f := st.Field(i)
+
+		if f.Type.Kind() == reflect.Ptr {
+			return true
+		}
+
+		st = f.Type
+	}
+
+	return false
+}
+
+// SetContext is called with gin context of the request on every new per-request instance
+func (c *Controller) SetContext(ctx *gin.Context) {
+	c.Ctx = ctx
+}
+
+// JSON renders obj as JSON with status code
+func (c *Controller) JSON(code int, obj interface{}) {
+	c.Ctx.JSON(code, obj)
+}
+
+// Render writes status code and renders r
+func (c *Controller) Render(code int, r render.Render) {
+	c.Ctx.Render(code, r)
+}
+
+// Redirect redirects to location with status code
+func (c *Controller) Redirect(code int, location string) {
+	c.Ctx.Redirect(code, location)
+}
+
+// Bind binds uri params, query and body (by content type) to obj and validates it the same way as typed actions do,
+// it doesn't write any response on error
+func (c *Controller) Bind(obj interface{}) error {
+	return bindRequest(c.Ctx, obj)
+}
+
+// Abort prevents pending handlers of the chain from being called
+func (c *Controller) Abort() {
+	c.Ctx.Abort()
+}
+
+// AbortWithStatus aborts the chain and writes status code
+func (c *Controller) AbortWithStatus(code int) {
+	c.Ctx.AbortWithStatus(code)
+}
@@ -0,0 +1,185 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testBaseReq struct {
+	ID   int    `uri:"id" binding:"required"`
+	Name string `form:"name" binding:"required"`
+}
+
+type ControllerBase struct {
+	Controller
+
+	greeting string
+	req      testBaseReq
+}
+
+func (c *ControllerBase) BeforeGetByID(ctx *gin.Context) {
+	if err := c.Bind(&c.req); err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+	}
+}
+
+func (c *ControllerBase) GetByID(ctx *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"greeting": c.greeting, "id": c.req.ID, "name": c.req.Name, "same": ctx == c.Ctx})
+}
+
+func (c *ControllerBase) GetText(ctx *gin.Context) {
+	c.Render(http.StatusOK, render.String{Format: "text %s", Data: []interface{}{c.greeting}})
+}
+
+func (c *ControllerBase) GetOld(ctx *gin.Context) {
+	c.Redirect(http.StatusMovedPermanently, "/base/text")
+}
+
+func (c *ControllerBase) GetDenied(ctx *gin.Context) {
+	c.Abort()
+}
+
+var (
+	testControllerBase1Values = []*testCase{
+		{http.MethodGet, "/base/1?name=john", http.StatusOK, `{"greeting":"hi","id":1,"name":"john","same":true}`},
+		{http.MethodGet, "/base/1", http.StatusBadRequest, ""},
+		{http.MethodGet, "/base/text", http.StatusOK, "text hi"},
+		{http.MethodGet, "/base/old", http.StatusMovedPermanently, "<a href=\"/base/text\">Moved Permanently</a>.\n\n"},
+		{http.MethodGet, "/base/denied", http.StatusOK, ""},
+	}
+)
+
+//
+// go test -count=1 -v -run TestRegisterControllerBase1
+
+func TestRegisterControllerBase1(t *testing.T) {
+
+	r := newRouter()
+
+	c := &ControllerBase{greeting: "hi"}
+
+	if err := AttachController(r, c); err != nil {
+		t.Error(err)
+		return
+	}
+
+	helperRunTestsForRouter(t, r, testControllerBase1Values)
+
+	// registered instance is prototype only
+	if c.Ctx != nil || c.req.ID != 0 {
+		t.Errorf("ControllerBase registered instance is modified by requests: %+v", c)
+	}
+}
+
+type ControllerBaseConcurrent struct {
+	Controller
+}
+
+func (c *ControllerBaseConcurrent) Get(ctx *gin.Context) {
+
+	c.Ctx.Header("X-Id", ctx.Query("id"))
+
+	// let concurrent requests overlap
+	time.Sleep(time.Millisecond)
+
+	if c.Ctx != ctx || c.Ctx.Writer.Header().Get("X-Id") != ctx.Query("id") {
+		c.AbortWithStatus(http.StatusConflict)
+		return
+	}
+
+	ctx.String(http.StatusOK, ctx.Query("id"))
+}
+
+type testBaseShared struct {
+	Controller
+}
+
+type ControllerBasePtr struct {
+	*Controller
+}
+
+func (c *ControllerBasePtr) Get(ctx *gin.Context) {}
+
+type ControllerBaseSharedPtr struct {
+	*testBaseShared
+}
+
+func (c *ControllerBaseSharedPtr) Get(ctx *gin.Context) {}
+
+//
+// go test -count=1 -v -run TestRegisterControllerBaseConcurrent1
+
+func TestRegisterControllerBaseConcurrent1(t *testing.T) {
+
+	r := newRouter()
+
+	if err := AttachController(r, &ControllerBaseConcurrent{}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	const n = 50
+
+	var wg sync.WaitGroup
+
+	codes := make([]int, n)
+
+	for i := 0; i < n; i++ {
+
+		wg.Add(1)
+
+		go func(i int) {
+
+			defer wg.Done()
+
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/base-concurrent/?id="+strconv.Itoa(i), nil))
+
+			codes[i] = w.Code
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, code := range codes {
+		if code != http.StatusOK {
+			t.Errorf("concurrent request #%d sees context of another request: status %d", i, code)
+		}
+	}
+
+	// shared Controller by pointer, both set and nil
+	for _, c := range []interface{}{
+		&ControllerBasePtr{&Controller{}},
+		&ControllerBasePtr{},
+		&ControllerBaseSharedPtr{&testBaseShared{}},
+	} {
+		if err := AttachController(newRouter(), c); err == nil {
+			t.Errorf("controller %T embedding Controller by pointer registered without error", c)
+		}
+	}
+}
@@ -0,0 +1,300 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+const (
+	// TagKey is the struct tag key of the controller fields options, e.g. `ginext:"inject"`
+	TagKey = "ginext"
+
+	tagInject   = "inject"
+	tagOptional = "optional"
+)
+
+type (
+	// Deps is a small injection container of the controller dependencies, which are resolved by type
+	// into fields tagged `ginext:"inject"` (`ginext:"inject,optional"`) and are available in
+	// `Init(deps *ginext.Deps) error` controller method
+	Deps struct {
+		mu        sync.Mutex
+		providers []*depsProvider
+	}
+
+	depsProvider struct {
+		t     reflect.Type
+		fn    reflect.Value // lazy provider func, invalid for value provider
+		once  sync.Once     // lazy provider call without Deps lock, so provider may resolve other dependencies
+		value reflect.Value
+		err   error
+	}
+
+	ControllerInitDepsMethod = func(deps *Deps) error
+)
+
+var ErrDepNotFound = errors.New("ginext: dependency not found")
+
+func NewDeps() *Deps {
+	return &Deps{}
+}
+
+// WithDeps sets the injection container of the registration
+func WithDeps(deps *Deps) Option {
+	return func(o *options) {
+		o.deps = deps
+	}
+}
+
+// Provide registers values as dependencies of their own (dynamic) types, which replace earlier providers of the same type
+func (d *Deps) Provide(values ...interface{}) *Deps {
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, v := range values {
+
+		rv := reflect.ValueOf(v)
+
+		if !rv.IsValid() {
+			continue
+		}
+
+		d.set(&depsProvider{t: rv.Type(), value: rv})
+	}
+
+	return d
+}
+
+// ProvideFunc registers lazy provider fn of signature `func() T` or `func() (T, error)` as dependency of type T,
+// fn is called at most once on the first resolve and may resolve other dependencies of d (but not cyclic ones)
+func (d *Deps) ProvideFunc(fn interface{}) error {
+
+	rv := reflect.ValueOf(fn)
+
+	if rv.Kind() != reflect.Func || rv.IsNil() {
+		return fmt.Errorf("ginext.Deps.ProvideFunc error: wrong provider %T, func expected", fn)
+	}
+
+	ft := rv.Type()
+
+	if ft.NumIn() != 0 || ft.NumOut() < 1 || ft.NumOut() > 2 || (ft.NumOut() == 2 && ft.Out(1) != errorType) {
+		return fmt.Errorf("ginext.Deps.ProvideFunc error: wrong provider signature %T", fn)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.set(&depsProvider{t: ft.Out(0), fn: rv})
+
+	return nil
+}
+
+// Resolve sets *ptr to the dependency of its type
+func (d *Deps) Resolve(ptr interface{}) error {
+
+	rv := reflect.ValueOf(ptr)
+
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("ginext.Deps.Resolve error: wrong target %T, non-nil pointer expected", ptr)
+	}
+
+	v, err := d.resolve(rv.Type().Elem())
+
+	if err != nil {
+		return err
+	}
+
+	rv.Elem().Set(v)
+
+	return nil
+}
+
+// ATN! must be called under lock
+func (d *Deps) set(p *depsProvider) {
+
+	for i, dp := range d.providers {
+		if dp.t == p.t {
+			d.providers[i] = p
+			return
+		}
+	}
+
+	d.providers = append(d.providers, p)
+}
+
+// resolve returns dependency of exactly type t or the only one implementing interface t
+func (d *Deps) resolve(t reflect.Type) (reflect.Value, error) {
+
+	found, err := d.lookup(t)
+
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	if found.fn.IsValid() {
+		found.once.Do(func() {
+
+			out := found.fn.Call(nil)
+
+			found.value = out[0]
+
+			if len(out) == 2 && !out[1].IsNil() {
+				found.err = out[1].Interface().(error)
+			}
+		})
+	}
+
+	if found.err != nil {
+		return reflect.Value{}, fmt.Errorf("ginext: dependency of type %v provider error: %w", found.t, found.err)
+	}
+
+	return found.value, nil
+}
+
+// lookup returns provider of the exact type t or the only provider implementing interface type t
+func (d *Deps) lookup(t reflect.Type) (*depsProvider, error) {
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var found *depsProvider
+
+	for _, p := range d.providers {
+		if p.t == t {
+			found = p
+			break
+		}
+	}
+
+	if found == nil && t.Kind() == reflect.Interface {
+		for _, p := range d.providers {
+
+			if !p.t.Implements(t) {
+				continue
+			}
+
+			if found != nil {
+				return nil, fmt.Errorf("ginext: ambiguous dependency of type %v: %v and %v", t, found.t, p.t)
+			}
+
+			found = p
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("%w: %v", ErrDepNotFound, t)
+	}
+
+	return found, nil
+}
+
+// inject sets zero fields tagged `ginext:"inject"` of the struct pointed by v (including embedded structs) to
+// the resolved dependencies, d may be nil if there are no tagged fields
+func (d *Deps) inject(v reflect.Value) error {
+
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+
+	return d.injectStruct(v.Elem())
+}
+
+func (d *Deps) injectStruct(sv reflect.Value) error {
+
+	st := sv.Type()
+
+	for i := 0; i < st.NumField(); i++ {
+
+		sf := st.Field(i)
+		fv := sv.Field(i)
+
+		if sf.Anonymous && sf.Type.Kind() == reflect.Struct && sf.IsExported() {
+			if err := d.injectStruct(fv); err != nil {
+				return err
+			}
+			continue
+		}
+
+		opts, _ := tagOptions(sf)
+
+		if _, ok := opts[tagInject]; !ok {
+			continue
+		}
+
+		if !sf.IsExported() {
+			return fmt.Errorf("field %s of %v is tagged %q, but is not exported", sf.Name, st, tagInject)
+		}
+
+		if !fv.IsZero() {
+			continue
+		}
+
+		if d == nil {
+			return fmt.Errorf("field %s of %v is tagged %q, but there is no WithDeps option", sf.Name, st, tagInject)
+		}
+
+		dep, err := d.resolve(sf.Type)
+
+		if err != nil {
+
+			if _, optional := opts[tagOptional]; optional && errors.Is(err, ErrDepNotFound) {
+				continue
+			}
+
+			return fmt.Errorf("field %s of %v: %w", sf.Name, st, err)
+		}
+
+		fv.Set(dep)
+	}
+
+	return nil
+}
+
+// tagOptions parses `ginext` tag of the field as comma separated list of `key` or `key=value` items
+func tagOptions(sf reflect.StructField) (map[string]string, bool) {
+
+	tag, ok := sf.Tag.Lookup(TagKey)
+
+	if !ok {
+		return nil, false
+	}
+
+	opts := make(map[string]string)
+
+	for _, item := range strings.Split(tag, ",") {
+
+		k, v := item, ""
+
+		if i := strings.IndexByte(item, '='); i >= 0 {
+			k, v = item[:i], item[i+1:]
+		}
+
+		if k = strings.TrimSpace(k); k != "" {
+			opts[k] = strings.TrimSpace(v)
+		}
+	}
+
+	return opts, true
+}
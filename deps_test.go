@@ -0,0 +1,209 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type testGreeter interface {
+	Greet() string
+}
+
+type testEnGreeter struct{}
+
+func (g *testEnGreeter) Greet() string {
+	return "hello"
+}
+
+type testConfig struct {
+	Name string
+}
+
+type testLogger struct {
+	lines []string
+}
+
+type ControllerDeps struct {
+	Greeter testGreeter `ginext:"inject"`
+	Config  *testConfig `ginext:"inject"`
+	Logger  *testLogger `ginext:"inject,optional"`
+
+	counter int
+}
+
+func (c *ControllerDeps) Init(deps *Deps) error {
+	return deps.Resolve(&c.counter)
+}
+
+func (c *ControllerDeps) Get(ctx *gin.Context) {
+	ctx.String(http.StatusOK, c.Greeter.Greet()+" "+c.Config.Name)
+}
+
+type ControllerBadDeps struct {
+	greeter testGreeter `ginext:"inject"`
+}
+
+func (c *ControllerBadDeps) Get(ctx *gin.Context) {}
+
+//
+// go test -count=1 -v -run TestRegisterControllerDeps1
+
+func TestRegisterControllerDeps1(t *testing.T) {
+
+	calls := 0
+
+	deps := NewDeps().Provide(&testEnGreeter{}, &testConfig{"prod"})
+
+	err := deps.ProvideFunc(func() (int, error) {
+		calls++
+		return 42, nil
+	})
+
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	r := newRouter()
+
+	c := &ControllerDeps{}
+
+	if err = AttachController(r, c, WithDeps(deps)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if c.counter != 42 || c.Logger != nil {
+		t.Errorf("ControllerDeps wrong injection: counter %d, logger %v", c.counter, c.Logger)
+		return
+	}
+
+	// the same controller type with fakes and lazy provider called once
+	fake := &ControllerDeps{Config: &testConfig{"fake"}}
+
+	if err = AttachController(r, fake, WithDeps(deps), WithPrefix("fake")); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if calls != 1 {
+		t.Errorf("ProvideFunc provider calls mismatch: want 1, got %d", calls)
+	}
+
+	helperRunTestsForRouter(t, r, []*testCase{
+		{http.MethodGet, "/deps/", http.StatusOK, "hello prod"},
+		{http.MethodGet, "/fake/", http.StatusOK, "hello fake"},
+	})
+
+	// missing dependency
+	if err = AttachController(newRouter(), &ControllerDeps{}, WithDeps(NewDeps())); !errors.Is(err, ErrDepNotFound) {
+		t.Errorf("ControllerDeps registration without providers wrong error: %v", err)
+	}
+
+	// no container
+	if err = AttachController(newRouter(), &ControllerDeps{}); err == nil {
+		t.Error("ControllerDeps registered without WithDeps")
+	}
+
+	// unexported field
+	if err = AttachController(newRouter(), &ControllerBadDeps{}, WithDeps(deps)); err == nil {
+		t.Error("ControllerBadDeps registered without error")
+	}
+
+	// ambiguous interface
+	ambiguous := NewDeps().Provide(&testEnGreeter{}, &testConfig{}, testAnotherGreeter{})
+
+	if err = AttachController(newRouter(), &ControllerDeps{}, WithDeps(ambiguous)); err == nil {
+		t.Error("ControllerDeps registered with ambiguous dependency")
+	}
+}
+
+type testAnotherGreeter struct{}
+
+func (g testAnotherGreeter) Greet() string {
+	return "hi"
+}
+
+type testRepo struct {
+	config *testConfig
+}
+
+//
+// go test -count=1 -v -run TestDepsNestedProviders1
+
+func TestDepsNestedProviders1(t *testing.T) {
+
+	deps := NewDeps()
+
+	calls := 0
+
+	if err := deps.ProvideFunc(func() *testConfig { calls++; return &testConfig{Name: "db"} }); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// provider resolves another lazy dependency of the same container
+	if err := deps.ProvideFunc(func() (*testRepo, error) {
+
+		var config *testConfig
+
+		if err := deps.Resolve(&config); err != nil {
+			return nil, err
+		}
+
+		return &testRepo{config}, nil
+	}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	done := make(chan struct{})
+
+	var (
+		repo *testRepo
+		err  error
+	)
+
+	go func() {
+		defer close(done)
+		err = deps.Resolve(&repo)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("nested providers deadlock")
+	}
+
+	if err != nil || repo == nil || repo.config == nil || repo.config.Name != "db" {
+		t.Errorf("nested providers resolve mismatch: %+v (err %v)", repo, err)
+		return
+	}
+
+	var config *testConfig
+
+	if err = deps.Resolve(&config); err != nil || config != repo.config || calls != 1 {
+		t.Errorf("lazy provider is not called once: %d calls, %p != %p (err %v)", calls, config, repo.config, err)
+	}
+}
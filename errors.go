@@ -0,0 +1,137 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+
+	problemTypeDefault = "about:blank"
+)
+
+type (
+	// ErrorHandlerFunc is the signature of controller's HandleError method and WithErrorHandler option,
+	// it handles errors returned by actions (including request binding errors)
+	ErrorHandlerFunc = func(ctx *gin.Context, err error)
+
+	// Problem is RFC7807 problem details object, which may be returned by actions as error itself
+	Problem struct {
+		Type     string `json:"type,omitempty"`
+		Title    string `json:"title,omitempty"`
+		Status   int    `json:"status,omitempty"`
+		Detail   string `json:"detail,omitempty"`
+		Instance string `json:"instance,omitempty"`
+	}
+)
+
+func (p *Problem) Error() string {
+
+	if p.Detail != "" {
+		return p.Detail
+	}
+
+	return p.Title
+}
+
+func (p *Problem) StatusCode() int {
+	return p.Status
+}
+
+// ProblemErrorHandler is the default error handler, which aborts request with RFC7807 problem+json body,
+// status is taken from the error chain (StatusCoder) or 500, error message is exposed as `detail`
+// only for client errors (4xx); *Problem in the error chain is rendered as is
+func ProblemErrorHandler(ctx *gin.Context, err error) {
+
+	if ctx.Writer.Written() {
+		ctx.Abort()
+		return
+	}
+
+	var p *Problem
+
+	if !errors.As(err, &p) {
+
+		status := errorStatus(err)
+
+		p = &Problem{
+			Type:   problemTypeDefault,
+			Title:  http.StatusText(status),
+			Status: status,
+		}
+
+		if status < http.StatusInternalServerError {
+			p.Detail = err.Error()
+		}
+	}
+
+	status := p.Status
+
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+
+	ctx.Header("Content-Type", ProblemContentType)
+	ctx.AbortWithStatusJSON(status, p)
+}
+
+func errorStatus(err error) int {
+
+	var sc StatusCoder
+
+	if errors.As(err, &sc) {
+		if status := sc.StatusCode(); status >= 100 && status <= 999 {
+			return status
+		}
+	}
+
+	return http.StatusInternalServerError
+}
+
+// WithErrorHandler sets handler of action errors for controllers without own HandleError method,
+// default is ProblemErrorHandler
+func WithErrorHandler(h ErrorHandlerFunc) Option {
+	return func(o *options) {
+		if h != nil {
+			o.errorHandler = h
+		}
+	}
+}
+
+func extractErrorHandlerMethod(instance interface{}, v *reflect.Value) (int, error) {
+
+	index, methodInstance := lookupMethod(v, "HandleError")
+
+	if index < 0 {
+		return -1, nil
+	}
+
+	if _, ok := methodInstance.(ErrorHandlerFunc); ok {
+		return index, nil
+	}
+
+	return -1, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has HandleError method with wrong signature %T", instance, methodInstance)
+}
@@ -0,0 +1,120 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type ControllerErrors struct {
+	t *testing.T
+}
+
+func (c *ControllerErrors) GetOk(ctx *gin.Context) error {
+	ctx.String(http.StatusOK, "ControllerErrors.GET:Ok")
+	return nil
+}
+
+func (c *ControllerErrors) GetMissing(ctx *gin.Context) error {
+	return NewHTTPError(http.StatusNotFound, errors.New("missing"))
+}
+
+func (c *ControllerErrors) GetProblem(ctx *gin.Context) error {
+	return &Problem{Type: "https://example.com/probs/out-of-credit", Title: "You do not have enough credit.", Status: http.StatusForbidden}
+}
+
+func (c *ControllerErrors) GetInternal(ctx *gin.Context) error {
+	return errors.New("secret internal details")
+}
+
+type ControllerHandleErrors struct {
+	ControllerErrors
+}
+
+func (c *ControllerHandleErrors) HandleError(ctx *gin.Context, err error) {
+	ctx.String(errorStatus(err), "ControllerHandleErrors.HandleError: "+err.Error())
+}
+
+type ControllerBadHandleError struct {
+	ControllerErrors
+}
+
+func (c *ControllerBadHandleError) HandleError(err error) {}
+
+//
+// go test -count=1 -v -run TestRegisterControllerErrors1
+
+var (
+	testControllerErrors1Values = []*testCase{
+		{http.MethodGet, "/errors/ok", http.StatusOK, "ControllerErrors.GET:Ok"},
+		{http.MethodGet, "/errors/missing", http.StatusNotFound, `{"type":"about:blank","title":"Not Found","status":404,"detail":"missing"}`},
+		{http.MethodGet, "/errors/problem", http.StatusForbidden, `{"type":"https://example.com/probs/out-of-credit","title":"You do not have enough credit.","status":403}`},
+		{http.MethodGet, "/errors/internal", http.StatusInternalServerError, `{"type":"about:blank","title":"Internal Server Error","status":500}`},
+		//
+		{http.MethodGet, "/handle-errors/ok", http.StatusOK, "ControllerErrors.GET:Ok"},
+		{http.MethodGet, "/handle-errors/missing", http.StatusNotFound, "ControllerHandleErrors.HandleError: missing"},
+		{http.MethodGet, "/handle-errors/internal", http.StatusInternalServerError, "ControllerHandleErrors.HandleError: secret internal details"},
+		//
+		{http.MethodGet, "/option/missing", http.StatusTeapot, "WithErrorHandler: missing"},
+	}
+)
+
+func TestRegisterControllerErrors1(t *testing.T) {
+
+	r := newRouter()
+
+	if err := AttachController(r, &ControllerErrors{t}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := AttachController(r, &ControllerHandleErrors{ControllerErrors{t}}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	onError := func(ctx *gin.Context, err error) {
+		ctx.String(http.StatusTeapot, "WithErrorHandler: "+err.Error())
+	}
+
+	if err := AttachController(r, &ControllerErrors{t}, WithPrefix("option"), WithErrorHandler(onError)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := AttachController(r, &ControllerBadHandleError{ControllerErrors{t}}); err == nil {
+		t.Error("ControllerBadHandleError registered without error")
+		return
+	}
+
+	helperRunTestsForRouter(t, r, testControllerErrors1Values)
+
+	// content type
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/errors/missing", nil))
+
+	if ct := w.Header().Get("Content-Type"); ct != ProblemContentType {
+		t.Errorf("problem response content type mismatch: want %q, got %q", ProblemContentType, ct)
+	}
+}
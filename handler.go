@@ -0,0 +1,265 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"net/http"
+	"reflect"
+)
+
+type (
+	// actionAdapter converts action method value (with bound receiver) to gin handler,
+	// onError handles errors returned by typed action
+	actionAdapter func(fn reflect.Value, onError ErrorHandlerFunc) gin.HandlerFunc
+
+	// StatusCoder is implemented by errors and action results to specify response http status
+	StatusCoder interface {
+		StatusCode() int
+	}
+
+	// HTTPError is an error with http status of the response
+	HTTPError struct {
+		Status int
+		Err    error
+	}
+
+	// BindError is returned when typed action request binding or validation fails
+	BindError struct {
+		Err error
+	}
+)
+
+var (
+	ginContextType  = reflect.TypeOf((*gin.Context)(nil))
+	errorType       = reflect.TypeOf((*error)(nil)).Elem()
+	handlerFuncType = reflect.TypeOf(HandlerFunc(nil))
+)
+
+func NewHTTPError(status int, err error) *HTTPError {
+	return &HTTPError{Status: status, Err: err}
+}
+
+func (e *HTTPError) Error() string {
+
+	if e.Err == nil {
+		return http.StatusText(e.Status)
+	}
+
+	return e.Err.Error()
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.Err
+}
+
+func (e *HTTPError) StatusCode() int {
+	return e.Status
+}
+
+func (e *BindError) Error() string {
+	return "ginext request binding error: " + e.Err.Error()
+}
+
+func (e *BindError) Unwrap() error {
+	return e.Err
+}
+
+func (e *BindError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+// newActionAdapter returns adapter for the action method type ft or nil if ft is not the valid action signature, which is
+//
+//	func(ctx *gin.Context)
+//
+// or typed one
+//
+//	func(ctx *gin.Context[, req *Request]) [(result Result[, err error]) | err error]
+//
+// where Request is a struct type, which is bound from uri params, query and body (by content type) and validated by gin
+// binding, result is rendered as JSON and error is passed to the controller's error handler
+func newActionAdapter(ft reflect.Type) actionAdapter {
+
+	// plain gin handler
+	if ft == handlerFuncType {
+		return func(fn reflect.Value, _ ErrorHandlerFunc) gin.HandlerFunc {
+			// ATN! not gin.HandlerFunc :: panic: interface conversion: interface {} is func(*gin.Context), not gin.HandlerFunc [recovered]
+			return fn.Interface().(HandlerFunc)
+		}
+	}
+
+	nIn, nOut := ft.NumIn(), ft.NumOut()
+
+	if nIn < 1 || nIn > 2 || ft.IsVariadic() || ft.In(0) != ginContextType {
+		return nil
+	}
+
+	var reqType reflect.Type
+
+	if nIn == 2 {
+		if reqType = ft.In(1); reqType.Kind() != reflect.Ptr || reqType.Elem().Kind() != reflect.Struct {
+			return nil
+		}
+	}
+
+	resultIndex, errIndex := -1, -1
+
+	switch nOut {
+	case 0:
+	case 1:
+		if ft.Out(0) == errorType {
+			errIndex = 0
+		} else {
+			resultIndex = 0
+		}
+	case 2:
+		if ft.Out(0) == errorType || ft.Out(1) != errorType {
+			return nil
+		}
+		resultIndex, errIndex = 0, 1
+	default:
+		return nil
+	}
+
+	return func(fn reflect.Value, onError ErrorHandlerFunc) gin.HandlerFunc {
+		return func(ctx *gin.Context) {
+
+			var in [2]reflect.Value
+
+			in[0] = reflect.ValueOf(ctx)
+			args := in[:1]
+
+			if reqType != nil {
+
+				req := reflect.New(reqType.Elem())
+
+				if err := bindRequest(ctx, req.Interface()); err != nil {
+					handleActionError(ctx, &BindError{err}, onError)
+					return
+				}
+
+				in[1], args = req, in[:2]
+			}
+
+			out := fn.Call(args)
+
+			if errIndex >= 0 {
+				if err, _ := out[errIndex].Interface().(error); err != nil {
+					handleActionError(ctx, err, onError)
+					return
+				}
+			}
+
+			if resultIndex >= 0 {
+				renderActionResult(ctx, out[resultIndex])
+			}
+		}
+	}
+}
+
+// bindRequest maps uri params and query to obj without validation, then binds body by content type
+// (with validation of the whole obj) or validates obj if there is no body
+func bindRequest(ctx *gin.Context, obj interface{}) error {
+
+	if len(ctx.Params) > 0 {
+
+		params := make(map[string][]string, len(ctx.Params))
+
+		for _, p := range ctx.Params {
+			params[p.Key] = []string{p.Value}
+		}
+
+		if err := binding.MapFormWithTag(obj, params, "uri"); err != nil {
+			return err
+		}
+	}
+
+	r := ctx.Request
+
+	if err := binding.MapFormWithTag(obj, r.URL.Query(), "form"); err != nil {
+		return err
+	}
+
+	if r.Body != nil && r.Body != http.NoBody && r.ContentLength != 0 {
+		return ctx.ShouldBindWith(obj, binding.Default(r.Method, ctx.ContentType()))
+	}
+
+	if binding.Validator == nil {
+		return nil
+	}
+
+	return binding.Validator.ValidateStruct(obj)
+}
+
+// renderActionResult renders non-nil result as JSON with status 200 or result's StatusCode,
+// nil pointer or interface result leads to 204 No Content, nil slice and map are rendered as empty ones
+func renderActionResult(ctx *gin.Context, result reflect.Value) {
+
+	// action has already written the response itself
+	if ctx.Writer.Written() {
+		return
+	}
+
+	if isNilValue(result) {
+		ctx.Status(http.StatusNoContent)
+		return
+	}
+
+	// ATN! nil slice and map are marshaled to JSON null, but clients of lists expect [] and {}
+	switch result.Kind() {
+	case reflect.Slice:
+		if result.IsNil() {
+			result = reflect.MakeSlice(result.Type(), 0, 0)
+		}
+	case reflect.Map:
+		if result.IsNil() {
+			result = reflect.MakeMap(result.Type())
+		}
+	}
+
+	v := result.Interface()
+	status := http.StatusOK
+
+	if sc, ok := v.(StatusCoder); ok {
+		status = sc.StatusCode()
+	}
+
+	ctx.JSON(status, v)
+}
+
+// handleActionError records err to ctx errors and passes it to the error handler
+func handleActionError(ctx *gin.Context, err error, onError ErrorHandlerFunc) {
+
+	_ = ctx.Error(err)
+
+	onError(ctx, err)
+}
+
+func isNilValue(v reflect.Value) bool {
+
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	}
+
+	return false
+}
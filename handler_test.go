@@ -0,0 +1,153 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+type testUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type testCreateUserReq struct {
+	Name  string `json:"name" binding:"required"`
+	Admin bool   `form:"admin"`
+}
+
+type testGetUserReq struct {
+	ID int `uri:"id" binding:"required"`
+}
+
+type testCreatedUser testUser
+
+func (u *testCreatedUser) StatusCode() int {
+	return http.StatusCreated
+}
+
+type ControllerTyped struct {
+	t *testing.T
+}
+
+func (c *ControllerTyped) PostUser(ctx *gin.Context, req *testCreateUserReq) (*testCreatedUser, error) {
+
+	if req.Admin {
+		return nil, NewHTTPError(http.StatusForbidden, errors.New("admin creation forbidden"))
+	}
+
+	return &testCreatedUser{ID: 1, Name: req.Name}, nil
+}
+
+func (c *ControllerTyped) GetUserByID(ctx *gin.Context, req *testGetUserReq) (*testUser, error) {
+
+	if req.ID != 1 {
+		return nil, NewHTTPError(http.StatusNotFound, errors.New("user not found"))
+	}
+
+	return &testUser{ID: req.ID, Name: "user"}, nil
+}
+
+func (c *ControllerTyped) GetNothing(ctx *gin.Context) (*testUser, error) {
+	return nil, nil
+}
+
+func (c *ControllerTyped) GetFail(ctx *gin.Context) (*testUser, error) {
+	return nil, errors.New("secret internal details")
+}
+
+func (c *ControllerTyped) GetList(ctx *gin.Context) []testUser {
+
+	if ctx.Query("empty") != "" {
+		return nil
+	}
+
+	return []testUser{{ID: 1, Name: "user"}}
+}
+
+func (c *ControllerTyped) GetTags(ctx *gin.Context) map[string]int {
+	return nil
+}
+
+type testBodyCase struct {
+	testCase
+	body string
+}
+
+func helperRunBodyTestsForRouter(t *testing.T, r *gin.Engine, cases []*testBodyCase) {
+
+	for _, tcase := range cases {
+
+		request := httptest.NewRequest(tcase.method, tcase.path, strings.NewReader(tcase.body))
+
+		if tcase.body != "" {
+			request.Header.Set("Content-Type", "application/json")
+		}
+
+		writer := httptest.NewRecorder()
+
+		r.ServeHTTP(writer, request)
+
+		t.Logf("%s %s == [%d] ==> %q", tcase.method, tcase.path, writer.Code, writer.Body.String())
+
+		if !helperCheckTestCaseResponseResult(t, &tcase.testCase, writer) {
+			return
+		}
+	}
+}
+
+//
+// go test -count=1 -v -run TestRegisterControllerTyped1
+
+var (
+	testControllerTyped1Values = []*testBodyCase{
+		{testCase{http.MethodPost, "/typed/user", http.StatusCreated, `{"id":1,"name":"john"}`}, `{"name":"john"}`},
+		{testCase{http.MethodPost, "/typed/user?admin=true", http.StatusForbidden, `{"type":"about:blank","title":"Forbidden","status":403,"detail":"admin creation forbidden"}`}, `{"name":"john"}`},
+		{testCase{http.MethodPost, "/typed/user", http.StatusBadRequest, `{"type":"about:blank","title":"Bad Request","status":400,"detail":"ginext request binding error: Key: 'testCreateUserReq.Name' Error:Field validation for 'Name' failed on the 'required' tag"}`}, `{}`},
+		{testCase{http.MethodPost, "/typed/user", http.StatusBadRequest, `{"type":"about:blank","title":"Bad Request","status":400,"detail":"ginext request binding error: invalid character '}' looking for beginning of value"}`}, `}`},
+		//
+		{testCase{http.MethodGet, "/typed/user/1", http.StatusOK, `{"id":1,"name":"user"}`}, ``},
+		{testCase{http.MethodGet, "/typed/user/2", http.StatusNotFound, `{"type":"about:blank","title":"Not Found","status":404,"detail":"user not found"}`}, ``},
+		{testCase{http.MethodGet, "/typed/user/abc", http.StatusBadRequest, `{"type":"about:blank","title":"Bad Request","status":400,"detail":"ginext request binding error: strconv.ParseInt: parsing \"abc\": invalid syntax"}`}, ``},
+		//
+		{testCase{http.MethodGet, "/typed/nothing", http.StatusNoContent, ``}, ``},
+		{testCase{http.MethodGet, "/typed/fail", http.StatusInternalServerError, `{"type":"about:blank","title":"Internal Server Error","status":500}`}, ``},
+		{testCase{http.MethodGet, "/typed/list", http.StatusOK, `[{"id":1,"name":"user"}]`}, ``},
+		// nil slice and map are empty lists, not No Content
+		{testCase{http.MethodGet, "/typed/list?empty=1", http.StatusOK, `[]`}, ``},
+		{testCase{http.MethodGet, "/typed/tags", http.StatusOK, `{}`}, ``},
+	}
+)
+
+func TestRegisterControllerTyped1(t *testing.T) {
+
+	r := newRouter()
+
+	if err := AttachController(r, &ControllerTyped{t}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	helperRunBodyTestsForRouter(t, r, testControllerTyped1Values)
+}
@@ -0,0 +1,123 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"net/http"
+	"strconv"
+)
+
+// headResponseWriter discards response body, but counts its length and defers writing of the headers
+// until the end of the handlers chain to set Content-Length SEE headHandler
+type headResponseWriter struct {
+	gin.ResponseWriter
+	size    int
+	written bool
+}
+
+// WithAutoHead adds HEAD route to every controller path with GET route, but without explicit HEAD one,
+// which runs the whole GET handlers chain with response body discarded, but headers and Content-Length kept
+func WithAutoHead() Option {
+	return func(o *options) {
+		o.autoHead = true
+	}
+}
+
+// headRoutes returns synthesized HEAD routes of the plan GET routes
+func (p *controllerPlan) headRoutes(o *options) (routes []controllerRoute) {
+
+	if !o.autoHead {
+		return nil
+	}
+
+	_, methods := p.pathMethods()
+
+	for i := range p.routes {
+
+		r := &p.routes[i]
+
+		if r.method != http.MethodGet || methods[r.path][http.MethodHead] {
+			continue
+		}
+
+		var chain handlersChain
+
+		chain.add(headHandler, "Head")
+		chain.concat(&r.chain)
+
+		routes = append(routes, controllerRoute{
+			method:      http.MethodHead,
+			path:        r.path,
+			name:        r.name,
+			controller:  r.controller,
+			chain:       chain,
+			synthesized: true,
+		})
+	}
+
+	return routes
+}
+
+func headHandler(ctx *gin.Context) {
+
+	w := &headResponseWriter{ResponseWriter: ctx.Writer}
+
+	ctx.Writer = w
+
+	defer func() {
+
+		ctx.Writer = w.ResponseWriter
+
+		if h := w.Header(); w.size > 0 && h.Get("Content-Length") == "" {
+			h.Set("Content-Length", strconv.Itoa(w.size))
+		}
+
+		if w.written {
+			w.ResponseWriter.WriteHeaderNow()
+		}
+	}()
+
+	ctx.Next()
+}
+
+func (w *headResponseWriter) Write(b []byte) (int, error) {
+	w.size += len(b)
+	w.written = true
+	return len(b), nil
+}
+
+func (w *headResponseWriter) WriteString(s string) (int, error) {
+	w.size += len(s)
+	w.written = true
+	return len(s), nil
+}
+
+func (w *headResponseWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *headResponseWriter) Written() bool {
+	return w.written || w.ResponseWriter.Written()
+}
+
+func (w *headResponseWriter) Size() int {
+	return w.size
+}
@@ -0,0 +1,88 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+type ControllerReports struct{}
+
+func (c *ControllerReports) GetDaily(ctx *gin.Context) {
+	ctx.Header("X-Report", "daily")
+	ctx.JSON(http.StatusOK, gin.H{"visits": 42})
+}
+
+func (c *ControllerReports) GetPing(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "pong")
+}
+
+func (c *ControllerReports) HeadPing(ctx *gin.Context) {
+	ctx.Header("X-Ping", "explicit")
+	ctx.Status(http.StatusOK)
+}
+
+func (c *ControllerReports) ActionAny(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "any")
+}
+
+//
+// go test -count=1 -v -run TestRegisterControllerAutoHead1
+
+func TestRegisterControllerAutoHead1(t *testing.T) {
+
+	r := newRouter()
+
+	if err := AttachController(r, &ControllerReports{}, WithAutoHead(), WithMethodNotAllowed()); err != nil {
+		t.Error(err)
+		return
+	}
+
+	get, head := httptest.NewRecorder(), httptest.NewRecorder()
+
+	r.ServeHTTP(get, httptest.NewRequest(http.MethodGet, "/reports/daily", nil))
+	r.ServeHTTP(head, httptest.NewRequest(http.MethodHead, "/reports/daily", nil))
+
+	if head.Code != http.StatusOK || head.Body.Len() != 0 {
+		t.Errorf("HEAD /reports/daily response mismatch: want < 200, empty body >, got < %d, %q >", head.Code, head.Body.String())
+	}
+
+	if cl := head.Header().Get("Content-Length"); cl != strconv.Itoa(get.Body.Len()) {
+		t.Errorf("HEAD /reports/daily Content-Length mismatch: want %d, got %q", get.Body.Len(), cl)
+	}
+
+	for _, h := range []string{"X-Report", "Content-Type"} {
+		if head.Header().Get(h) != get.Header().Get(h) {
+			t.Errorf("HEAD /reports/daily header %s mismatch: want %q, got %q", h, get.Header().Get(h), head.Header().Get(h))
+		}
+	}
+
+	// explicit HEAD route
+	head = httptest.NewRecorder()
+
+	r.ServeHTTP(head, httptest.NewRequest(http.MethodHead, "/reports/ping", nil))
+
+	if head.Code != http.StatusOK || head.Header().Get("X-Ping") != "explicit" {
+		t.Errorf("HEAD /reports/ping is not explicit one: %d %v", head.Code, head.Header())
+	}
+}
@@ -0,0 +1,241 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	tagMount = "mount"
+	tagSkip  = "-"
+)
+
+// controllerWrap is the outer part of the handlers chains of the controller routes, which is inherited
+// by its sub-controllers: their chains are { parent prefix..., own chain..., parent suffix... }
+type controllerWrap struct {
+	prefix, suffix handlersChain
+	mounted        []interface{} // the controller and all its parents to catch mount cycles
+}
+
+// wrapController returns wrap of the instance without own handlers inheriting parent ones
+func wrapController(parent *controllerWrap, instance interface{}) *controllerWrap {
+
+	w := &controllerWrap{}
+
+	if parent != nil {
+		w.prefix.concat(&parent.prefix)
+		w.mounted = append(w.mounted, parent.mounted...)
+	}
+
+	w.mounted = append(w.mounted, instance)
+
+	return w
+}
+
+// qualified returns copy of the wrap with names of the owner handlers qualified by the owner name
+// to distinguish them from the same sub-controller handlers, e.g. ControllerPosts.Before
+func (w *controllerWrap) qualified(owner string) *controllerWrap {
+
+	q := &controllerWrap{mounted: w.mounted}
+
+	for _, c := range [...]struct{ src, dst *handlersChain }{{&w.prefix, &q.prefix}, {&w.suffix, &q.suffix}} {
+		for i, h := range c.src.handlers {
+
+			name := c.src.names[i]
+
+			// ATN! middleware names and already qualified names of parents contain dot
+			if !strings.Contains(name, ".") {
+				name = owner + "." + name
+			}
+
+			c.dst.add(h, name)
+		}
+	}
+
+	return q
+}
+
+func (w *controllerWrap) isMounted(instance interface{}) bool {
+
+	for _, m := range w.mounted {
+		if m == instance {
+			return true
+		}
+	}
+
+	return false
+}
+
+// planSubControllers plans sub-controllers from the fields of the controller struct under its endpoint
+func (p *controllerPlan) planSubControllers(rg RouterGroup, v *reflect.Value, endpoint string, o *options, wrap *controllerWrap) error {
+
+	e := v.Elem()
+
+	if e.Kind() != reflect.Struct {
+		return nil
+	}
+
+	st := e.Type()
+
+	for i, n := 0, st.NumField(); i < n; i++ {
+
+		sf := st.Field(i)
+
+		segment, ok, tagged, err := subControllerSegment(sf, o)
+
+		if err != nil {
+			return fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T: %w", p.instance, err)
+		}
+
+		if !ok {
+			continue
+		}
+
+		fv := e.Field(i)
+
+		// ATN! untagged field is only auto-detected by its type, so nil one is just not mounted
+		if fv.IsNil() {
+
+			if !tagged {
+				continue
+			}
+
+			return fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has nil sub-controller field %s", p.instance, sf.Name)
+		}
+
+		instance := fv.Interface()
+
+		if wrap.isMounted(instance) {
+			return fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has sub-controller field %s mounted in cycle", p.instance, sf.Name)
+		}
+
+		// sub-controller inherits parent options except own ones of the registration
+		co := *o
+		co.prefix, co.hasPrefix = strings.TrimPrefix(endpoint+"/"+segment, "/"), true
+		co.middleware = nil // inherited within parent prefix
+		co.perRequest, co.create = false, nil
+
+		child, err := planController(rg, instance, true, &co, wrap.qualified(st.Name()))
+
+		if err != nil {
+			return err
+		}
+
+		p.children = append(p.children, child)
+		p.routes = append(p.routes, child.routes...)
+	}
+
+	return nil
+}
+
+// subControllerSegment returns path segment of the sub-controller field under the parent endpoint,
+// which is value of `ginext:"mount=segment"` tag or field name converted by naming strategy;
+// untagged exported field is sub-controller if its type is pointer to struct named with controller prefix or suffix
+// (SEE SetControllerAffixes) or embedding Controller, `ginext:"-"` skips the field; mount reports whether the field
+// is tagged `ginext:"mount"`
+func subControllerSegment(sf reflect.StructField, o *options) (segment string, ok, mount bool, err error) {
+
+	// embedded controllers are not sub-controllers, their methods are promoted to the parent
+	if sf.Anonymous {
+		return "", false, false, nil
+	}
+
+	opts, _ := tagOptions(sf)
+
+	if _, skip := opts[tagSkip]; skip {
+		return "", false, false, nil
+	}
+
+	// dependencies are never sub-controllers
+	if _, inject := opts[tagInject]; inject {
+		return "", false, false, nil
+	}
+
+	segment, mount = opts[tagMount]
+
+	if !mount {
+		if !sf.IsExported() || !isControllerType(sf.Type, &o.affixes) {
+			return "", false, false, nil
+		}
+	} else if !sf.IsExported() {
+		return "", false, true, fmt.Errorf("field %s is tagged %q, but is not exported", sf.Name, tagMount)
+	} else if k := sf.Type.Kind(); k != reflect.Ptr && k != reflect.Interface {
+		return "", false, true, fmt.Errorf("field %s is tagged %q, but is not pointer or interface", sf.Name, tagMount)
+	}
+
+	if segment = strings.Trim(segment, "/"); segment == "" {
+		segment = o.naming(sf.Name)
+	}
+
+	return segment, true, mount, nil
+}
+
+// isControllerType reports whether t is pointer to struct named with controller affix or embedding Controller
+func isControllerType(t reflect.Type, affixes *controllerAffixes) bool {
+
+	if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return false
+	}
+
+	if name, ok := affixes.trim(t.Elem().Name()); ok && name != "" {
+		return true
+	}
+
+	return t.Implements(reflect.TypeOf((*contextSetter)(nil)).Elem())
+}
+
+// setup injects dependencies and calls Init of the controller and then of its sub-controllers
+func (p *controllerPlan) setup() error {
+
+	if err := p.deps.inject(reflect.ValueOf(p.instance)); err != nil {
+		return fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T injection err: %w", p.instance, err)
+	}
+
+	if p.init != nil {
+		// early call before method registration
+		if err := p.init(p.absPath); err != nil {
+			return fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T Init err: %w", p.instance, err)
+		}
+	}
+
+	for _, child := range p.children {
+		if err := child.setup(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// controllers returns the controller and all its sub-controllers with Shutdown method in the setup order
+func (p *controllerPlan) controllers() (cs []registeredController) {
+
+	if p.shutdown != nil {
+		cs = append(cs, registeredController{p.instance, p.shutdown})
+	}
+
+	for _, child := range p.children {
+		cs = append(cs, child.controllers()...)
+	}
+
+	return cs
+}
@@ -0,0 +1,192 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type ControllerPosts struct {
+	Comments *ControllerComments
+	Admin    *adminUsers        `ginext:"mount=admin/users"`
+	Skipped  *ControllerSkipped `ginext:"-"`
+}
+
+func (c *ControllerPosts) Before(ctx *gin.Context) {
+	ctx.Writer.WriteString("pb ")
+}
+
+func (c *ControllerPosts) After(ctx *gin.Context) {
+	ctx.Writer.WriteString("pa")
+}
+
+func (c *ControllerPosts) Get(ctx *gin.Context) {
+	ctx.Writer.WriteString("posts ")
+}
+
+type ControllerComments struct {
+	absPath string
+}
+
+func (c *ControllerComments) Init(absPath string) error {
+	c.absPath = absPath
+	return nil
+}
+
+func (c *ControllerComments) Before(ctx *gin.Context) {
+	ctx.Writer.WriteString("cb ")
+}
+
+func (c *ControllerComments) After(ctx *gin.Context) {
+	ctx.Writer.WriteString("ca ")
+}
+
+func (c *ControllerComments) Get(ctx *gin.Context) {
+	ctx.Writer.WriteString("comments ")
+}
+
+func (c *ControllerComments) GetBy_Id(ctx *gin.Context) {
+	ctx.Writer.WriteString("comment " + ctx.Param("id") + " ")
+}
+
+type adminUsers struct{}
+
+func (c *adminUsers) Get(ctx *gin.Context) {
+	ctx.Writer.WriteString("users ")
+}
+
+type ControllerSkipped struct{}
+
+func (c *ControllerSkipped) Get(ctx *gin.Context) {}
+
+type ControllerMountNil struct {
+	Comments *ControllerComments
+}
+
+func (c *ControllerMountNil) Get(ctx *gin.Context) {}
+
+type ControllerMountNilTagged struct {
+	Comments *ControllerComments `ginext:"mount"`
+}
+
+func (c *ControllerMountNilTagged) Get(ctx *gin.Context) {}
+
+type ControllerMountCycle struct {
+	Self *ControllerMountCycle `ginext:"mount"`
+}
+
+func (c *ControllerMountCycle) Get(ctx *gin.Context) {}
+
+type ControllerMountUnexported struct {
+	users *adminUsers `ginext:"mount"`
+}
+
+func (c *ControllerMountUnexported) Get(ctx *gin.Context) {}
+
+var (
+	testControllerPosts1Values = []*testCase{
+		{http.MethodGet, "/posts/", http.StatusOK, "pb posts pa"},
+		{http.MethodGet, "/posts/comments/", http.StatusOK, "pb cb comments ca pa"},
+		{http.MethodGet, "/posts/comments/7", http.StatusOK, "pb cb comment 7 ca pa"},
+		{http.MethodGet, "/posts/admin/users/", http.StatusOK, "pb users pa"},
+		{http.MethodGet, "/posts/skipped/", http.StatusNotFound, "404 page not found"},
+	}
+)
+
+//
+// go test -count=1 -v -run TestRegisterControllerMount1
+
+func TestRegisterControllerMount1(t *testing.T) {
+
+	r := newRouter()
+
+	registry := NewRegistry()
+
+	posts := &ControllerPosts{
+		Comments: &ControllerComments{},
+		Admin:    &adminUsers{},
+		Skipped:  &ControllerSkipped{},
+	}
+
+	if err := AttachController(r, posts, WithRegistry(registry)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	helperRunTestsForRouter(t, r, testControllerPosts1Values)
+
+	if posts.Comments.absPath != "/posts/comments" {
+		t.Errorf("ControllerComments Init absPath mismatch: want /posts/comments, got %s", posts.Comments.absPath)
+	}
+
+	wantHandlers := []string{"ControllerPosts.Before", "Before", "Get", "After", "ControllerPosts.After"}
+
+	found := false
+
+	for _, ri := range registry.Routes() {
+		if ri.Method == http.MethodGet && ri.Path == "/posts/comments/" {
+
+			found = true
+
+			if ri.Controller != reflect.TypeOf(posts.Comments) {
+				t.Errorf("route %s controller mismatch: want %T, got %v", ri.Path, posts.Comments, ri.Controller)
+			}
+
+			if !reflect.DeepEqual(ri.Handlers, wantHandlers) {
+				t.Errorf("route %s handlers mismatch: want %v, got %v", ri.Path, wantHandlers, ri.Handlers)
+			}
+		}
+	}
+
+	if !found {
+		t.Error("route GET /posts/comments/ is not in registry")
+	}
+
+	// nil untagged sub-controller field is not mounted
+	{
+		r := newRouter()
+
+		if err := AttachController(r, &ControllerMountNil{}); err != nil {
+			t.Errorf("controller %T with nil untagged sub-controller registration err: %v", &ControllerMountNil{}, err)
+		} else if routes := r.Routes(); len(routes) != 1 || routes[0].Path != "/mount-nil/" {
+			t.Errorf("controller %T with nil untagged sub-controller routes mismatch: %v", &ControllerMountNil{}, routes)
+		}
+	}
+
+	// bad sub-controllers
+	cycle := &ControllerMountCycle{}
+	cycle.Self = cycle
+
+	for _, c := range []interface{}{&ControllerMountNilTagged{}, cycle, &ControllerMountUnexported{}} {
+
+		r := newRouter()
+
+		if err := AttachController(r, c); err == nil {
+			t.Errorf("controller %T with bad sub-controller registered without error", c)
+		} else if len(r.Routes()) != 0 {
+			t.Errorf("controller %T with bad sub-controller registered routes: %v", c, r.Routes())
+		} else if !strings.Contains(err.Error(), "sub-controller") && !strings.Contains(err.Error(), tagMount) {
+			t.Errorf("controller %T unexpected error: %v", c, err)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/stoewer/go-strcase"
+
+	"sort"
+	"strings"
+	"sync"
+)
+
+// controllerAffixes are stripped from the controller type name to get the controller endpoint,
+// lists are sorted for the longest match
+type controllerAffixes struct {
+	prefixes, suffixes []string
+}
+
+var (
+	controllerAffixesMu sync.RWMutex
+
+	// default for every registration without WithControllerPrefixes / WithControllerSuffixes options
+	controllerAffixesDefault = controllerAffixes{prefixes: []string{ControllerPrefix}}
+)
+
+// KebabCase is the default naming strategy: UserInfo => user-info
+func KebabCase(name string) string {
+	return strcase.KebabCase(name)
+}
+
+// SnakeCase naming strategy: UserInfo => user_info
+func SnakeCase(name string) string {
+	return strcase.SnakeCase(name)
+}
+
+// LowerCamelCase naming strategy: UserInfo => userInfo
+func LowerCamelCase(name string) string {
+	return strcase.LowerCamelCase(name)
+}
+
+// Verbatim naming strategy keeps go name as is: UserInfo => UserInfo
+func Verbatim(name string) string {
+	return name
+}
+
+// SetControllerAffixes sets default prefixes and suffixes stripped from the controller type names of all subsequent
+// registrations (default is the only ControllerPrefix prefix), e.g. for `UserController` or `UsersHandler` names
+// SetControllerAffixes(nil, []string{"Controller", "Handler"}); use WithControllerPrefixes / WithControllerSuffixes
+// options to set them for single registration
+func SetControllerAffixes(prefixes, suffixes []string) {
+
+	a := controllerAffixes{
+		prefixes: sortAffixes(prefixes),
+		suffixes: sortAffixes(suffixes),
+	}
+
+	controllerAffixesMu.Lock()
+	controllerAffixesDefault = a
+	controllerAffixesMu.Unlock()
+}
+
+// WithControllerPrefixes overrides SetControllerAffixes default prefixes for the registration
+func WithControllerPrefixes(prefixes ...string) Option {
+	return func(o *options) {
+		o.affixes.prefixes = sortAffixes(prefixes)
+	}
+}
+
+// WithControllerSuffixes overrides SetControllerAffixes default suffixes for the registration
+func WithControllerSuffixes(suffixes ...string) Option {
+	return func(o *options) {
+		o.affixes.suffixes = sortAffixes(suffixes)
+	}
+}
+
+func defaultControllerAffixes() controllerAffixes {
+
+	controllerAffixesMu.RLock()
+	defer controllerAffixesMu.RUnlock()
+
+	return controllerAffixesDefault
+}
+
+// sortAffixes returns copy of non-empty affixes sorted longest first
+func sortAffixes(affixes []string) []string {
+
+	sorted := make([]string, 0, len(affixes))
+
+	for _, a := range affixes {
+		if a != "" {
+			sorted = append(sorted, a)
+		}
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return len(sorted[i]) > len(sorted[j])
+	})
+
+	return sorted
+}
+
+// trim strips the longest matching prefix and the longest matching suffix from the controller type name
+// and reports whether any of them is matched
+func (a *controllerAffixes) trim(name string) (string, bool) {
+
+	matched := false
+
+	for _, p := range a.prefixes {
+		if strings.HasPrefix(name, p) {
+			name, matched = name[len(p):], true
+			break
+		}
+	}
+
+	for _, s := range a.suffixes {
+		if strings.HasSuffix(name, s) {
+			name, matched = name[:len(name)-len(s)], true
+			break
+		}
+	}
+
+	return name, matched
+}
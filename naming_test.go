@@ -0,0 +1,136 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"testing"
+)
+
+type ControllerUserInfo struct{}
+
+func (c *ControllerUserInfo) GetLastLogin(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "last login")
+}
+
+type UserController struct{}
+
+func (c *UserController) Get(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "user")
+}
+
+type UsersAPI struct{}
+
+func (c *UsersAPI) Get(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "users")
+}
+
+var (
+	testNaming1Values = []*testCase{
+		{http.MethodGet, "/kebab/user-info/last-login", http.StatusOK, "last login"},
+		{http.MethodGet, "/snake/user_info/last_login", http.StatusOK, "last login"},
+		{http.MethodGet, "/camel/userInfo/lastLogin", http.StatusOK, "last login"},
+		{http.MethodGet, "/verbatim/UserInfo/LastLogin", http.StatusOK, "last login"},
+		{http.MethodGet, "/custom/USERINFO/LASTLOGIN", http.StatusOK, "last login"},
+		//
+		{http.MethodGet, "/user/", http.StatusOK, "user"},
+		{http.MethodGet, "/users/", http.StatusOK, "users"},
+	}
+
+	testControllerAffixes1Cases = []struct {
+		prefixes, suffixes []string
+		in, out            string
+		matched            bool
+	}{
+		{[]string{ControllerPrefix}, nil, "ControllerUser", "User", true},
+		{[]string{ControllerPrefix}, nil, "UserController", "UserController", false},
+		{nil, []string{"Controller", "Handler", "API"}, "UsersHandler", "Users", true},
+		{[]string{"Ctl", "Ctrl"}, []string{"API", "PublicAPI"}, "CtrlUsersPublicAPI", "Users", true},
+		{[]string{ControllerPrefix}, []string{"API"}, "Controller", "", true},
+		{nil, nil, "Users", "Users", false},
+	}
+)
+
+//
+// go test -count=1 -v -run TestControllerAffixes1
+
+func TestControllerAffixes1(t *testing.T) {
+
+	for _, c := range testControllerAffixes1Cases {
+
+		a := controllerAffixes{sortAffixes(c.prefixes), sortAffixes(c.suffixes)}
+
+		if out, matched := a.trim(c.in); out != c.out || matched != c.matched {
+			t.Errorf("trim %q by %v %v mismatch: want %q %v, got %q %v", c.in, c.prefixes, c.suffixes, c.out, c.matched, out, matched)
+		}
+	}
+}
+
+//
+// go test -count=1 -v -run TestNamingStrategy1
+
+func TestNamingStrategy1(t *testing.T) {
+
+	r := newRouter()
+
+	for prefix, naming := range map[string]NamingStrategy{
+		"kebab":    KebabCase,
+		"snake":    SnakeCase,
+		"camel":    LowerCamelCase,
+		"verbatim": Verbatim,
+		"custom":   testUpperNaming,
+	} {
+		if err := AttachController(r.Group(prefix), &ControllerUserInfo{}, WithNamingStrategy(naming)); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	if err := AttachController(r, &UserController{}, WithControllerPrefixes(), WithControllerSuffixes("Controller")); err != nil {
+		t.Error(err)
+		return
+	}
+
+	SetControllerAffixes(nil, []string{"Handler", "API"})
+
+	err := AttachController(r, &UsersAPI{})
+
+	SetControllerAffixes([]string{ControllerPrefix}, nil)
+
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	helperRunTestsForRouter(t, r, testNaming1Values)
+}
+
+func testUpperNaming(name string) string {
+
+	b := []byte(name)
+
+	for i, c := range b {
+		if c >= 'a' && c <= 'z' {
+			b[i] = c - 'a' + 'A'
+		}
+	}
+
+	return string(b)
+}
@@ -0,0 +1,232 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+type (
+	// Option configures single controller registration by AttachController / EmbedController
+	Option func(o *options)
+
+	// NamingStrategy converts CamelCase part of the go name to the router path segment
+	NamingStrategy func(name string) string
+)
+
+type options struct {
+	appendTrailingSlash bool
+
+	// verbs must be followed by word boundary SEE WithStrictVerbs
+	strictVerbs bool
+
+	// http methods of `Action` methods, nil means all SEE WithActionMethods
+	actionMethods []string
+
+	// multi-verb names without connector SEE WithConcatVerbs
+	concatVerbs bool
+
+	// methods excluded from routes decoding SEE WithExclude
+	exclude []string
+
+	// REST resource methods SEE WithResource
+	resource bool
+
+	// synthesized routes SEE WithAutoHead, WithAutoOptions, WithMethodNotAllowed
+	autoHead         bool
+	autoOptions      bool
+	methodNotAllowed bool
+
+	naming NamingStrategy
+
+	affixes controllerAffixes
+
+	prefix    string
+	hasPrefix bool
+
+	// nil means global registry SEE RegisterHttpMethod
+	httpMethods []string
+
+	middleware []gin.HandlerFunc
+
+	registry *Registry // nil means DefaultRegistry of controllers only SEE WithRegistry
+
+	errorHandler ErrorHandlerFunc
+
+	deps *Deps
+
+	// nil means engine of the router group if it is known SEE WithEngine
+	engine *gin.Engine
+
+	// Before and After of all the controller embedding levels SEE WithChainedWrappers
+	chainedWrappers bool
+
+	// per-request controller instances SEE WithPerRequest, AttachControllerFactory
+	perRequest bool
+	create     func() reflect.Value
+}
+
+// default for every registration without WithTrailingSlash option
+// ATN! atomic, 0 - off, 1 - on
+var appendTrailingSlashDefault int32
+
+// AppendTrailingSlash sets default trailing slash policy of all subsequent registrations,
+// use WithTrailingSlash option to set it for single registration
+func AppendTrailingSlash(on bool) {
+
+	var v int32
+
+	if on {
+		v = 1
+	}
+
+	atomic.StoreInt32(&appendTrailingSlashDefault, v)
+}
+
+// default for every registration without WithStrictVerbs option
+// ATN! atomic, 0 - off, 1 - on
+var strictVerbsDefault int32
+
+// StrictVerbs sets default verb parsing mode of all subsequent registrations SEE WithStrictVerbs
+func StrictVerbs(on bool) {
+
+	var v int32
+
+	if on {
+		v = 1
+	}
+
+	atomic.StoreInt32(&strictVerbsDefault, v)
+}
+
+func newOptions(opts []Option) *options {
+
+	o := &options{
+		appendTrailingSlash: atomic.LoadInt32(&appendTrailingSlashDefault) != 0,
+		strictVerbs:         atomic.LoadInt32(&strictVerbsDefault) != 0,
+		naming:              KebabCase,
+		affixes:             defaultControllerAffixes(),
+		errorHandler:        ProblemErrorHandler,
+	}
+
+	for _, opt := range opts {
+		if opt != nil {
+			opt(o)
+		}
+	}
+
+	return o
+}
+
+// WithTrailingSlash overrides AppendTrailingSlash default for the registration
+func WithTrailingSlash(on bool) Option {
+	return func(o *options) {
+		o.appendTrailingSlash = on
+	}
+}
+
+// WithStrictVerbs overrides StrictVerbs default for the registration: in strict mode http method (and `Action`)
+// prefix of the method name must be title-cased word followed by uppercase letter, digit, `_` or the end of the name,
+// so `Header`, `Getaway`, `Deleted` are not actions, but `Get`, `GetUser`, `Delete_` are
+func WithStrictVerbs(on bool) Option {
+	return func(o *options) {
+		o.strictVerbs = on
+	}
+}
+
+// WithActionMethods restricts http methods of `Action` methods to the methods set (e.g. GET, POST, PUT, PATCH, DELETE)
+// instead of all ones (gin Any); `ActionMethods() []string` controller method takes precedence over this option
+func WithActionMethods(methods ...string) Option {
+	return func(o *options) {
+		o.actionMethods = append([]string{}, methods...)
+	}
+}
+
+// WithConcatVerbs allows multi-verb method names without `Or` connector, e.g. `PutPatchProfile` for PUT and PATCH
+// /profile; NOTE endpoint starting with verb word (e.g. `GetOptions`) becomes ambiguous in this mode
+func WithConcatVerbs() Option {
+	return func(o *options) {
+		o.concatVerbs = true
+	}
+}
+
+// WithExclude excludes methods from routes decoding, e.g. exported helpers named with http method prefix,
+// the same as `ginext:"exclude=Method1|Method2"` tag of any controller struct field (e.g. `_ struct{}`)
+func WithExclude(methods ...string) Option {
+	return func(o *options) {
+		o.exclude = append(o.exclude, methods...)
+	}
+}
+
+// WithNamingStrategy sets conversion of controller name and endpoint parts of method names to path segments,
+// default is KebabCase, also SnakeCase, LowerCamelCase, Verbatim or any custom func are available
+func WithNamingStrategy(naming NamingStrategy) Option {
+	return func(o *options) {
+		if naming != nil {
+			o.naming = naming
+		}
+	}
+}
+
+// WithPrefix sets path prefix of all controller endpoints instead of converted controller name (AttachController)
+// or empty one (EmbedController)
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		o.prefix, o.hasPrefix = prefix, true
+	}
+}
+
+// WithHttpMethods restricts recognized method name prefixes to the methods set instead of global one
+// (rfc methods + RegisterHttpMethod)
+func WithHttpMethods(methods ...string) Option {
+	return func(o *options) {
+		o.httpMethods = append([]string{}, methods...)
+	}
+}
+
+// WithMiddleware prepends handlers to the handlers chain of every controller endpoint
+func WithMiddleware(handlers ...gin.HandlerFunc) Option {
+	return func(o *options) {
+		o.middleware = append(o.middleware, handlers...)
+	}
+}
+
+// httpMethodsList returns the effective recognized http methods sorted for the longest prefix match
+func (o *options) httpMethodsList() ([]string, error) {
+
+	if o.httpMethods == nil {
+
+		httpMethodsMu.RLock()
+		defer httpMethodsMu.RUnlock()
+
+		return httpMethods, nil
+	}
+
+	for _, m := range o.httpMethods {
+		if !isValidHttpMethod(m) {
+			return nil, fmt.Errorf("wrong http method %q in WithHttpMethods option", m)
+		}
+	}
+
+	return sortHttpMethods(o.httpMethods), nil
+}
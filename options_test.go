@@ -0,0 +1,87 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/stoewer/go-strcase"
+	"net/http"
+	"testing"
+)
+
+type ControllerOptions struct {
+	t *testing.T
+}
+
+func (c *ControllerOptions) GetUserName(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "ControllerOptions.GET:UserName "+ctx.GetString("mw"))
+}
+
+func (c *ControllerOptions) PatchUserName(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "ControllerOptions.PATCH:UserName")
+}
+
+//
+// go test -count=1 -v -run TestRegisterControllerOptions1
+
+var (
+	testControllerOptions1Values = []*testCase{
+		// default
+		{http.MethodGet, "/options/user-name", http.StatusOK, "ControllerOptions.GET:UserName "},
+		{http.MethodPatch, "/options/user-name", http.StatusOK, "ControllerOptions.PATCH:UserName"},
+		// trailing slash + naming + middleware
+		{http.MethodGet, "/options/user_name/", http.StatusOK, "ControllerOptions.GET:UserName mw"},
+		// custom prefix + http methods set
+		{http.MethodGet, "/v2/custom/user-name", http.StatusOK, "ControllerOptions.GET:UserName "},
+		{http.MethodPatch, "/v2/custom/user-name", http.StatusMethodNotAllowed, errStr405},
+	}
+)
+
+func TestRegisterControllerOptions1(t *testing.T) {
+
+	r := newRouter()
+
+	c := &ControllerOptions{t}
+
+	mw := func(ctx *gin.Context) {
+		ctx.Set("mw", "mw")
+	}
+
+	if err := AttachController(r, c); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := AttachController(r, c, WithTrailingSlash(true), WithNamingStrategy(strcase.SnakeCase), WithMiddleware(mw)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := EmbedController(r, c, WithPrefix("/v2/custom/"), WithHttpMethods(http.MethodGet)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := EmbedController(r, c, WithHttpMethods("get")); err == nil {
+		t.Error("WithHttpMethods accepts lowercase method")
+		return
+	}
+
+	helperRunTestsForRouter(t, r, testControllerOptions1Values)
+}
@@ -0,0 +1,216 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"fmt"
+	"reflect"
+)
+
+const routeMethodAny = "ANY"
+
+type (
+	// controllerPlan is the fully validated controller registration, which is not applied to router yet
+	controllerPlan struct {
+		instance interface{}
+		deps     *Deps
+		absPath  string // absolute mount path of the controller
+		init     ControllerInitAbsPathMethod
+		shutdown ControllerShutdownMethod
+		engine   *gin.Engine       // engine of the router group to check existing routes or nil if unknown
+		routes   []controllerRoute // including routes of sub-controllers
+		children []*controllerPlan // sub-controllers
+		registry *Registry
+	}
+
+	controllerRoute struct {
+		method     string // http method or methodActionNotch
+		path       string // relative to router group
+		name       string // go method name
+		controller reflect.Type
+		chain      handlersChain
+		// synthesized route is added by registration options, not declared by controller SEE allowRoutes, headRoutes
+		synthesized bool
+	}
+
+	// handlersChain is gin handlers chain with names of handlers SEE RouteInfo.Handlers
+	handlersChain struct {
+		handlers []gin.HandlerFunc
+		names    []string
+	}
+
+	// RouteConflictError is returned when gin refuses to add the controller route,
+	// e.g. because of conflict with another route or wrong wildcard
+	RouteConflictError struct {
+		Method string // http method or ANY for Action methods
+		Path   string // relative to router group
+		Action string // go method name
+		Reason string // gin panic message
+	}
+)
+
+// basePath returns base path of the rg or "/" for plain gin.IRoutes
+func basePath(rg RouterGroup) string {
+
+	if bp, ok := rg.(BasePathRouterGroup); ok {
+		return bp.BasePath()
+	}
+
+	return "/"
+}
+
+func (e *RouteConflictError) Error() string {
+	return fmt.Sprintf(errRegisterControllerPrefix+"route %s %s of action method %s conflict: %s", e.Method, e.Path, e.Action, e.Reason)
+}
+
+// WithEngine sets engine of the router group, whose already registered routes are checked for conflicts
+// before the registration, e.g. for *gin.RouterGroup or custom gin.IRoutes wrappers; *gin.Engine router
+// is its own engine without this option
+func WithEngine(engine *gin.Engine) Option {
+	return func(o *options) {
+		o.engine = engine
+	}
+}
+
+// routerEngine returns engine of the rg or nil if it is unknown
+// NOTE gin does not expose engine of *gin.RouterGroup SEE WithEngine
+func routerEngine(rg RouterGroup) *gin.Engine {
+
+	if e, ok := rg.(*gin.Engine); ok {
+		return e
+	}
+
+	return nil
+}
+
+// dryRun replays all the plan routes (and already existing routes of the engine, if it is known)
+// on the scratch engine to catch gin route conflicts before the real registration
+// NOTE in gin debug mode scratch engine prints its routes as any other engine does
+func (p *controllerPlan) dryRun(rg RouterGroup) error {
+
+	scratch := gin.New()
+
+	noop := func(*gin.Context) {}
+
+	if p.engine != nil {
+		for _, ri := range p.engine.Routes() {
+			// ATN! existing routes were successfully registered, so never panic here
+			scratch.Handle(ri.Method, ri.Path, noop)
+		}
+	}
+
+	group := scratch.Group(basePath(rg))
+
+	for i := range p.routes {
+
+		r := &p.routes[i]
+
+		if err := r.handle(group, noop); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// commit adds all the plan routes to rg
+func (p *controllerPlan) commit(rg RouterGroup) error {
+
+	for i := range p.routes {
+
+		r := &p.routes[i]
+
+		if err := r.handle(rg, r.chain.handlers...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handle adds route to rg recovering gin panic to RouteConflictError
+func (r *controllerRoute) handle(rg gin.IRoutes, handlers ...gin.HandlerFunc) (err error) {
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = &RouteConflictError{
+				Method: r.httpMethod(),
+				Path:   r.path,
+				Action: r.name,
+				Reason: fmt.Sprint(rec),
+			}
+		}
+	}()
+
+	if r.method == methodActionNotch {
+		rg.Any(r.path, handlers...)
+	} else {
+		rg.Handle(r.method, r.path, handlers...)
+	}
+
+	return nil
+}
+
+func (r *controllerRoute) httpMethod() string {
+
+	if r.method == methodActionNotch {
+		return routeMethodAny
+	}
+
+	return r.method
+}
+
+// add appends non-nil handler h with its name to the chain
+func (c *handlersChain) add(h gin.HandlerFunc, name string) {
+
+	if h == nil {
+		return
+	}
+
+	c.handlers = append(c.handlers, h)
+	c.names = append(c.names, name)
+}
+
+// concat appends all handlers of the other chain
+func (c *handlersChain) concat(other *handlersChain) {
+	c.handlers = append(c.handlers, other.handlers...)
+	c.names = append(c.names, other.names...)
+}
+
+// wrap composes all the chain handlers into single handler called inside around hook as its next func,
+// handlers after aborting one are skipped as gin does
+func (c *handlersChain) wrap(around AroundHookFunc) gin.HandlerFunc {
+
+	handlers := c.handlers
+
+	return func(ctx *gin.Context) {
+		around(ctx, func() {
+			for _, h := range handlers {
+
+				if ctx.IsAborted() {
+					return
+				}
+
+				h(ctx)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"testing"
+)
+
+type ControllerConflict struct {
+	t *testing.T
+}
+
+func (c *ControllerConflict) GetUserByID(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "ControllerConflict.GET:UserByID")
+}
+
+func (c *ControllerConflict) GetUserByName(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "ControllerConflict.GET:UserByName")
+}
+
+type ControllerTestInitNotCalled struct {
+	t *testing.T
+}
+
+func (c *ControllerTestInitNotCalled) Init() error {
+	c.t.Error("ControllerTestInitNotCalled.Init executed!")
+	return nil
+}
+
+func (c *ControllerTestInitNotCalled) GetEndpoint(ctx *gin.Context) {
+	ctx.Status(http.StatusOK)
+}
+
+func (c *ControllerTestInitNotCalled) PostEndpoint(ctx *gin.Context, err error) {
+	ctx.Status(http.StatusOK)
+}
+
+//
+// go test -count=1 -v -run TestRegisterControllerTransactional1
+
+func TestRegisterControllerTransactional1(t *testing.T) {
+
+	// bad method signature
+	r := newRouter()
+
+	if err := registerController(r, &ControllerBadMethod{t}, true); err == nil {
+		t.Error("ControllerBadMethod registered without error")
+		return
+	}
+
+	if routes := r.Routes(); len(routes) != 0 {
+		t.Errorf("ControllerBadMethod registration error leaves routes %v", routes)
+		return
+	}
+
+	// no Init on validation error
+	if err := registerController(r, &ControllerTestInitNotCalled{t}, true); err == nil {
+		t.Error("ControllerTestInitNotCalled registered without error")
+		return
+	}
+
+	// conflict inside the controller
+	var conflict *RouteConflictError
+
+	err := registerController(r, &ControllerConflict{t}, true)
+
+	if !errors.As(err, &conflict) {
+		t.Errorf("ControllerConflict registration wrong error: %v", err)
+		return
+	}
+
+	t.Logf("ControllerConflict registration error: %v", err)
+
+	if routes := r.Routes(); len(routes) != 0 {
+		t.Errorf("ControllerConflict registration error leaves routes %v", routes)
+		return
+	}
+
+	// conflict with existing engine route
+	r.POST("/common/data", func(ctx *gin.Context) {})
+
+	if err = registerController(r, &ControllerCommon{t: t}, true); !errors.As(err, &conflict) {
+		t.Errorf("ControllerCommon registration wrong error: %v", err)
+		return
+	}
+
+	if routes := r.Routes(); len(routes) != 1 {
+		t.Errorf("ControllerCommon registration error leaves routes %v", routes)
+		return
+	}
+
+	// conflict with itself under the router group
+	g := r.Group("/group")
+
+	if err = registerController(g, &ControllerCommon{t: t}, true); err != nil {
+		t.Error(err)
+		return
+	}
+
+	n := len(r.Routes())
+
+	if err = registerController(g, &ControllerCommon{t: t}, true, WithEngine(r)); !errors.As(err, &conflict) {
+		t.Errorf("ControllerCommon second registration wrong error: %v", err)
+		return
+	}
+
+	if routes := r.Routes(); len(routes) != n {
+		t.Errorf("ControllerCommon second registration error leaves routes %v", routes[n:])
+		return
+	}
+
+	// conflict with existing route of the router group, whose engine is set by option
+	r = newRouter()
+
+	api := r.Group("/api")
+
+	api.GET("/probe-a/y", func(ctx *gin.Context) {})
+
+	if err = registerController(api, &ControllerProbeA{}, true, WithEngine(r)); !errors.As(err, &conflict) {
+		t.Errorf("ControllerProbeA registration wrong error: %v", err)
+		return
+	}
+
+	if routes := r.Routes(); len(routes) != 1 {
+		t.Errorf("ControllerProbeA registration error leaves routes %v", routes)
+		return
+	}
+
+	// conflict with existing route of the engine of wrapped router
+	r = newRouter()
+
+	r.GET("/probe-a/y", func(ctx *gin.Context) {})
+
+	if err = registerController(struct{ gin.IRoutes }{r}, &ControllerProbeA{}, true, WithEngine(r)); !errors.As(err, &conflict) {
+		t.Errorf("ControllerProbeA wrapped router registration wrong error: %v", err)
+		return
+	}
+
+	if routes := r.Routes(); len(routes) != 1 {
+		t.Errorf("ControllerProbeA wrapped router registration error leaves routes %v", routes)
+		return
+	}
+}
+
+type ControllerProbeA struct{}
+
+func (c *ControllerProbeA) GetX(ctx *gin.Context) {}
+
+func (c *ControllerProbeA) GetY(ctx *gin.Context) {}
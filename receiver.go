@@ -0,0 +1,255 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"fmt"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+)
+
+type (
+	// controllerReceiver binds controller methods either to the shared controller instance at registration
+	// or to the per-request instance, which is created by the first handler of the chain and stored in gin ctx
+	controllerReceiver struct {
+		v      reflect.Value        // shared instance or prototype of per-request ones
+		create func() reflect.Value // nil for shared instance
+		key    string               // gin ctx key of per-request instance
+		deps   *Deps                // injected into every instance created by factory, copies share prototype ones
+	}
+
+	// methodConv converts controller method value with bound receiver to gin handler
+	methodConv func(fn reflect.Value) gin.HandlerFunc
+)
+
+const receiverKeyPrefix = "ginext.controller#"
+
+var receiverSeq uint64
+
+// AttachControllerFactory is AttachController for per-request controller instances, which are created by factory of
+// signature `func() *T` (where *T is controller type) for every request, so they may safely use per-request fields;
+// Init and Shutdown methods are called on the instance created once at registration, dependencies (SEE WithDeps)
+// are injected into every instance
+func AttachControllerFactory(rg RouterGroup, factory interface{}, opts ...Option) error {
+	return registerControllerFactory(rg, factory, true, opts)
+}
+
+// EmbedControllerFactory is EmbedController for per-request controller instances SEE AttachControllerFactory
+func EmbedControllerFactory(rg RouterGroup, factory interface{}, opts ...Option) error {
+	return registerControllerFactory(rg, factory, false, opts)
+}
+
+// WithPerRequest makes shallow copy of the registered controller instance (prototype) for every request,
+// so copies may safely use per-request fields
+func WithPerRequest() Option {
+	return func(o *options) {
+		o.perRequest = true
+	}
+}
+
+func registerControllerFactory(rg RouterGroup, factory interface{}, prependControllerEndpoint bool, opts []Option) error {
+
+	fv := reflect.ValueOf(factory)
+
+	if fv.Kind() != reflect.Func || fv.IsNil() {
+		return fmt.Errorf(errRegisterControllerPrefix+"wrong controller factory type: %T", factory)
+	}
+
+	if ft := fv.Type(); ft.NumIn() != 0 || ft.NumOut() != 1 || ft.Out(0).Kind() != reflect.Ptr {
+		return fmt.Errorf(errRegisterControllerPrefix+"wrong controller factory signature %T, func() *T expected", factory)
+	}
+
+	create := func() reflect.Value {
+		return fv.Call(nil)[0]
+	}
+
+	proto := create()
+
+	if proto.IsNil() {
+		return fmt.Errorf(errRegisterControllerPrefix+"controller factory %T returns nil", factory)
+	}
+
+	opts = append(opts[:len(opts):len(opts)], func(o *options) {
+		o.create = create
+	})
+
+	return registerController(rg, proto.Interface(), prependControllerEndpoint, opts...)
+}
+
+func newControllerReceiver(v reflect.Value, o *options) *controllerReceiver {
+
+	r := &controllerReceiver{
+		v:      v,
+		create: o.create,
+	}
+
+	if o.create != nil {
+		r.deps = o.deps
+	}
+
+	// controllers with embedded Controller are always per-request ones
+	_, isContextSetter := v.Interface().(contextSetter)
+
+	if r.create == nil && (o.perRequest || isContextSetter) && v.Kind() == reflect.Ptr {
+
+		t := v.Type().Elem()
+
+		r.create = func() reflect.Value {
+
+			c := reflect.New(t)
+			c.Elem().Set(v.Elem())
+
+			return c
+		}
+	}
+
+	if r.create != nil {
+		r.key = receiverKeyPrefix + strconv.FormatUint(atomic.AddUint64(&receiverSeq, 1), 10)
+	}
+
+	return r
+}
+
+// instantiate returns handler, which creates per-request instance, or nil for shared instance
+func (r *controllerReceiver) instantiate() gin.HandlerFunc {
+
+	if r.create == nil {
+		return nil
+	}
+
+	create, key, deps := r.create, r.key, r.deps
+
+	return func(ctx *gin.Context) {
+
+		c := create()
+
+		if c.IsNil() {
+			panic("ginext: controller factory returns nil instance")
+		}
+
+		// NOTE resolved dependencies are cached, and the same injection of the prototype succeeded at registration
+		if err := deps.inject(c); err != nil {
+			panic(fmt.Errorf("ginext: controller instance %v of type %[1]T injection err: %w", c.Interface(), err))
+		}
+
+		if cs, ok := c.Interface().(contextSetter); ok {
+			cs.SetContext(ctx)
+		}
+
+		ctx.Set(key, c)
+	}
+}
+
+// current returns receiver of the request
+func (r *controllerReceiver) current(ctx *gin.Context) reflect.Value {
+
+	if r.create == nil {
+		return r.v
+	}
+
+	return ctx.MustGet(r.key).(reflect.Value)
+}
+
+// bind returns gin handler converted by conv from the method #index bound to the receiver or nil if index < 0
+func (r *controllerReceiver) bind(index int, conv methodConv) gin.HandlerFunc {
+
+	if index < 0 {
+		return nil
+	}
+
+	if r.create == nil {
+		return conv(r.v.Method(index))
+	}
+
+	return func(ctx *gin.Context) {
+		conv(r.current(ctx).Method(index))(ctx)
+	}
+}
+
+// bindField is bind for the method #index of the embedded field of the receiver with index sequence path SEE embeddedReceiver
+func (r *controllerReceiver) bindField(path []int, index int, conv methodConv) gin.HandlerFunc {
+
+	if len(path) == 0 {
+		return r.bind(index, conv)
+	}
+
+	if r.create == nil {
+		return conv(fieldReceiver(r.v, path).Method(index))
+	}
+
+	return func(ctx *gin.Context) {
+		conv(fieldReceiver(r.current(ctx), path).Method(index))(ctx)
+	}
+}
+
+func fieldReceiver(v reflect.Value, path []int) reflect.Value {
+
+	for _, i := range path {
+		v = embeddedReceiver(v.Elem().Field(i))
+	}
+
+	return v
+}
+
+// bindErrorHandler returns HandleError method #index bound to the receiver
+func (r *controllerReceiver) bindErrorHandler(index int) ErrorHandlerFunc {
+
+	if r.create == nil {
+		return r.v.Method(index).Interface().(ErrorHandlerFunc)
+	}
+
+	return func(ctx *gin.Context, err error) {
+		r.current(ctx).Method(index).Interface().(ErrorHandlerFunc)(ctx, err)
+	}
+}
+
+func handlerConv(fn reflect.Value) gin.HandlerFunc {
+	// ATN! not gin.HandlerFunc :: panic: interface conversion: interface {} is func(*gin.Context), not gin.HandlerFunc [recovered]
+	return fn.Interface().(HandlerFunc)
+}
+
+func finallyConv(fn reflect.Value) gin.HandlerFunc {
+	return deferHandler(handlerConv(fn))
+}
+
+func actionHookConv(action string) methodConv {
+	return func(fn reflect.Value) gin.HandlerFunc {
+
+		hook := fn.Interface().(ActionHookFunc)
+
+		return func(ctx *gin.Context) {
+			hook(ctx, action)
+		}
+	}
+}
+
+func aroundConv(inner *handlersChain) methodConv {
+	return func(fn reflect.Value) gin.HandlerFunc {
+		return inner.wrap(fn.Interface().(AroundHookFunc))
+	}
+}
+
+func actionConv(adapter actionAdapter, onError ErrorHandlerFunc) methodConv {
+	return func(fn reflect.Value) gin.HandlerFunc {
+		return adapter(fn, onError)
+	}
+}
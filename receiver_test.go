@@ -0,0 +1,167 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"testing"
+)
+
+type ControllerStateful struct {
+	prefix string // set by Init, shared by copies
+	user   string // per-request
+	hooks  int    // per-request
+}
+
+func (c *ControllerStateful) Init() error {
+	c.prefix = "hello"
+	return nil
+}
+
+func (c *ControllerStateful) Before(ctx *gin.Context) {
+	c.user = ctx.Query("user")
+	c.hooks++
+}
+
+func (c *ControllerStateful) BeforeAction(ctx *gin.Context, action string) {
+	c.hooks++
+}
+
+func (c *ControllerStateful) Get(ctx *gin.Context) {
+	ctx.String(http.StatusOK, c.prefix+" "+c.user+" "+strconv.Itoa(c.hooks))
+}
+
+func (c *ControllerStateful) GetFail(ctx *gin.Context) error {
+	return errors.New(c.user)
+}
+
+func (c *ControllerStateful) HandleError(ctx *gin.Context, err error) {
+	ctx.String(http.StatusTeapot, "HandleError "+c.user+": "+err.Error())
+}
+
+var (
+	testControllerStateful1Values = []*testCase{
+		{http.MethodGet, "/stateful/?user=john", http.StatusOK, "hello john 2"},
+		{http.MethodGet, "/stateful/?user=jane", http.StatusOK, "hello jane 2"},
+		{http.MethodGet, "/stateful/", http.StatusOK, "hello  2"},
+		{http.MethodGet, "/stateful/fail?user=john", http.StatusTeapot, "HandleError john: john"},
+		//
+		{http.MethodGet, "/factory/?user=john", http.StatusOK, "factory john 2"},
+		{http.MethodGet, "/factory/?user=jane", http.StatusOK, "factory jane 2"},
+		{http.MethodGet, "/factory/fail?user=jane", http.StatusTeapot, "HandleError jane: jane"},
+	}
+)
+
+//
+// go test -count=1 -v -run TestRegisterControllerPerRequest1
+
+func TestRegisterControllerPerRequest1(t *testing.T) {
+
+	r := newRouter()
+
+	// prototype copies
+	proto := &ControllerStateful{}
+
+	if err := AttachController(r, proto, WithPerRequest()); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// factory
+	created := 0
+
+	factory := func() *ControllerStateful {
+		created++
+		return &ControllerStateful{prefix: "factory"}
+	}
+
+	if err := AttachControllerFactory(r, factory, WithPrefix("factory")); err != nil {
+		t.Error(err)
+		return
+	}
+
+	helperRunTestsForRouter(t, r, testControllerStateful1Values)
+
+	if proto.prefix != "hello" || proto.user != "" || proto.hooks != 0 {
+		t.Errorf("ControllerStateful prototype is modified by requests: %+v", proto)
+	}
+
+	// 1 at registration + 1 per request
+	if created != 4 {
+		t.Errorf("ControllerStateful factory calls mismatch: want 4, got %d", created)
+	}
+
+	// bad factories
+	for _, f := range []interface{}{nil, proto, func() ControllerStateful { return ControllerStateful{} }, func() *ControllerStateful { return nil }} {
+		if err := AttachControllerFactory(newRouter(), f); err == nil {
+			t.Errorf("controller factory %T registered without error", f)
+		}
+	}
+}
+
+type ControllerFactoryDeps struct {
+	Config *testConfig `ginext:"inject"`
+	user   string
+}
+
+func (c *ControllerFactoryDeps) Before(ctx *gin.Context) {
+	c.user = ctx.Query("user")
+}
+
+func (c *ControllerFactoryDeps) Get(ctx *gin.Context) {
+
+	name := "<nil>"
+
+	if c.Config != nil {
+		name = c.Config.Name
+	}
+
+	ctx.String(http.StatusOK, name+" "+c.user)
+}
+
+var (
+	testControllerFactoryDeps1Values = []*testCase{
+		{http.MethodGet, "/factory-deps/?user=john", http.StatusOK, "app john"},
+		{http.MethodGet, "/factory-deps/?user=jane", http.StatusOK, "app jane"},
+	}
+)
+
+//
+// go test -count=1 -v -run TestRegisterControllerFactoryDeps1
+
+func TestRegisterControllerFactoryDeps1(t *testing.T) {
+
+	r := newRouter()
+
+	deps := NewDeps().Provide(&testConfig{Name: "app"})
+
+	factory := func() *ControllerFactoryDeps {
+		return &ControllerFactoryDeps{}
+	}
+
+	if err := AttachControllerFactory(r, factory, WithDeps(deps)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	helperRunTestsForRouter(t, r, testControllerFactoryDeps1Values)
+}
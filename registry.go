@@ -0,0 +1,232 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"runtime"
+	"sync"
+)
+
+type (
+	// RouteInfo describes single route added by controller registration
+	RouteInfo struct {
+		Method     string       // http method
+		Path       string       // full (absolute) path
+		Controller reflect.Type // controller instance type
+		Action     string       // go method name of the action
+		Handlers   []string     // whole handlers chain names: middleware func names and controller method names
+		// Synthesized is true for HEAD, OPTIONS and 405 routes added by WithAutoHead, WithAutoOptions
+		// and WithMethodNotAllowed options, but not declared by the controller
+		Synthesized bool
+	}
+
+	// Registry collects routes and controllers with Shutdown method of all registrations with WithRegistry option
+	// and shuts the controllers down; DefaultRegistry collects only controllers of the registrations without it
+	Registry struct {
+		mu          sync.Mutex
+		routes      []RouteInfo
+		controllers []registeredController
+	}
+
+	registeredController struct {
+		instance interface{}
+		shutdown ControllerShutdownMethod
+	}
+
+	ControllerShutdownMethod = func(ctx context.Context) error
+)
+
+// DefaultRegistry remembers controllers with Shutdown method (but not routes) of all registrations without
+// WithRegistry option
+var DefaultRegistry = NewRegistry()
+
+// ATN! same as gin anyMethods, used by RouterGroup.Any
+var anyHttpMethods = [...]string{
+	http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+	http.MethodHead, http.MethodOptions, http.MethodDelete, http.MethodConnect,
+	http.MethodTrace,
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// WithRegistry collects routes and controllers of the registration into the registry instead of DefaultRegistry
+func WithRegistry(registry *Registry) Option {
+	return func(o *options) {
+		if registry != nil {
+			o.registry = registry
+		}
+	}
+}
+
+// Routes returns copy of all collected routes in the registration order
+func (r *Registry) Routes() []RouteInfo {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]RouteInfo(nil), r.routes...)
+}
+
+func (r *Registry) add(controllers []registeredController, routes []RouteInfo) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.routes = append(r.routes, routes...)
+
+next:
+	for _, c := range controllers {
+
+		// the same instance may be registered several times, but must be shut down once
+		for i := range r.controllers {
+			if r.controllers[i].instance == c.instance {
+				continue next
+			}
+		}
+
+		r.controllers = append(r.controllers, c)
+	}
+}
+
+// Shutdown calls Shutdown(ctx) methods of all the registered controllers in reverse registration order,
+// every controller is called even if ctx is done (so it may see done ctx and release resources quickly);
+// it returns the first controller error or ctx error if ctx is done; shut down controllers are forgotten
+func (r *Registry) Shutdown(ctx context.Context) (err error) {
+
+	r.mu.Lock()
+	controllers := r.controllers
+	r.controllers = nil
+	r.mu.Unlock()
+
+	for i := len(controllers) - 1; i >= 0; i-- {
+
+		c := &controllers[i]
+
+		if err2 := c.shutdown(ctx); err2 != nil && err == nil {
+			err = fmt.Errorf("ginext: controller instance %v of type %[1]T shutdown err: %w", c.instance, err2)
+		}
+	}
+
+	if err == nil {
+		err = ctx.Err()
+	}
+
+	return err
+}
+
+// ShutdownServer gracefully shuts srv down first and then all the registered controllers
+func (r *Registry) ShutdownServer(ctx context.Context, srv *http.Server) error {
+
+	err := srv.Shutdown(ctx)
+
+	if err2 := r.Shutdown(ctx); err == nil {
+		err = err2
+	}
+
+	return err
+}
+
+// Shutdown shuts down all controllers of DefaultRegistry SEE Registry.Shutdown
+func Shutdown(ctx context.Context) error {
+	return DefaultRegistry.Shutdown(ctx)
+}
+
+// ShutdownServer gracefully shuts srv down and then all controllers of DefaultRegistry SEE Registry.ShutdownServer
+func ShutdownServer(ctx context.Context, srv *http.Server) error {
+	return DefaultRegistry.ShutdownServer(ctx, srv)
+}
+
+func extractShutdownMethod(instance interface{}, v *reflect.Value) (ControllerShutdownMethod, error) {
+
+	methodValue := v.MethodByName("Shutdown")
+
+	// SEE https://github.com/golang/go/issues/46320#issuecomment-1081940201
+	if !methodValue.IsValid() || methodValue.IsNil() {
+		return nil, nil
+	}
+
+	if m, ok := methodValue.Interface().(ControllerShutdownMethod); ok {
+		return m, nil
+	}
+
+	return nil, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has Shutdown method with wrong signature %T", instance, methodValue.Interface())
+}
+
+// routesInfo returns info of all the plan routes relative to rg
+func (p *controllerPlan) routesInfo(rg RouterGroup) []RouteInfo {
+
+	base := basePath(rg)
+
+	routes := make([]RouteInfo, 0, len(p.routes))
+
+	for i := range p.routes {
+
+		r := &p.routes[i]
+
+		ri := RouteInfo{
+			Path:        joinPaths(base, r.path),
+			Controller:  r.controller,
+			Action:      r.name,
+			Handlers:    r.chain.names,
+			Synthesized: r.synthesized,
+		}
+
+		if r.method != methodActionNotch {
+			ri.Method = r.method
+			routes = append(routes, ri)
+			continue
+		}
+
+		for _, m := range anyHttpMethods {
+			ri.Method = m
+			routes = append(routes, ri)
+		}
+	}
+
+	return routes
+}
+
+// same as gin joinPaths
+func joinPaths(absolutePath, relativePath string) string {
+
+	if relativePath == "" {
+		return absolutePath
+	}
+
+	finalPath := path.Join(absolutePath, relativePath)
+
+	if relativePath[len(relativePath)-1] == '/' && finalPath[len(finalPath)-1] != '/' {
+		return finalPath + "/"
+	}
+
+	return finalPath
+}
+
+func nameOfFunction(f gin.HandlerFunc) string {
+	return runtime.FuncForPC(reflect.ValueOf(f).Pointer()).Name()
+}
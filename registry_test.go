@@ -0,0 +1,284 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testRegistryMiddleware(ctx *gin.Context) {}
+
+//
+// go test -count=1 -v -run TestRegistryRoutes1
+
+func TestRegistryRoutes1(t *testing.T) {
+
+	r := newRouter()
+
+	reg := NewRegistry()
+
+	if err := AttachController(r.Group("/api"), &ControllerTestWrappers{t, nil}, WithRegistry(reg), WithMiddleware(testRegistryMiddleware)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := AttachController(r, &ControllerCommon{t: t, quiet: true}, WithRegistry(reg)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	routes := reg.Routes()
+
+	for _, ri := range routes {
+		t.Logf("%-6s %-25s %v.%s %v", ri.Method, ri.Path, ri.Controller, ri.Action, ri.Handlers)
+	}
+
+	if engineRoutes := r.Routes(); len(routes) != len(engineRoutes) {
+		t.Errorf("registry routes count mismatch: want %d, got %d", len(engineRoutes), len(routes))
+		return
+	}
+
+	want := RouteInfo{
+		Method:     http.MethodPut,
+		Path:       "/api/test-wrappers/index",
+		Controller: reflect.TypeOf(&ControllerTestWrappers{}),
+		Action:     "PutIndex",
+		Handlers:   []string{"github.com/Illirgway/go-ginext.testRegistryMiddleware", "Before", "PutIndex", "After"},
+	}
+
+	if !reflect.DeepEqual(routes[0], want) {
+		t.Errorf("registry route mismatch: want %+v, got %+v", want, routes[0])
+		return
+	}
+
+	// Action method expands to all http methods
+	known := 0
+
+	for _, ri := range routes {
+		if ri.Action == "ActionKnown" && ri.Path == "/common/known" {
+			known++
+		}
+	}
+
+	if known != len(anyHttpMethods) {
+		t.Errorf("registry ActionKnown routes count mismatch: want %d, got %d", len(anyHttpMethods), known)
+	}
+}
+
+type testShutdownLog struct {
+	seq []string
+}
+
+type ControllerShutdownFirst struct {
+	log *testShutdownLog
+}
+
+func (c *ControllerShutdownFirst) Get(ctx *gin.Context) {}
+
+func (c *ControllerShutdownFirst) Shutdown(ctx context.Context) error {
+	c.log.seq = append(c.log.seq, "first")
+	return nil
+}
+
+type ControllerShutdownSecond struct {
+	log *testShutdownLog
+}
+
+func (c *ControllerShutdownSecond) Get(ctx *gin.Context) {}
+
+func (c *ControllerShutdownSecond) Shutdown(ctx context.Context) error {
+	c.log.seq = append(c.log.seq, "second")
+	return errors.New("second shutdown error")
+}
+
+// Close is not a shutdown method
+func (c *ControllerShutdownSecond) Close(ctx context.Context) {
+	c.log.seq = append(c.log.seq, "close")
+}
+
+type ControllerShutdownBad struct{}
+
+func (c *ControllerShutdownBad) Get(ctx *gin.Context) {}
+
+func (c *ControllerShutdownBad) Shutdown() {}
+
+//
+// go test -count=1 -v -run TestRegistryShutdown1
+
+func TestRegistryShutdown1(t *testing.T) {
+
+	r := newRouter()
+
+	reg := NewRegistry()
+
+	log := &testShutdownLog{}
+
+	first := &ControllerShutdownFirst{log}
+
+	for _, c := range []interface{}{first, &ControllerCommon{t: t, quiet: true}, &ControllerShutdownSecond{log}} {
+		if err := AttachController(r, c, WithRegistry(reg)); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	// the same instance twice
+	if err := AttachController(r, first, WithRegistry(reg), WithPrefix("again")); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := AttachController(r, &ControllerShutdownBad{}, WithRegistry(reg)); err == nil {
+		t.Error("ControllerShutdownBad registered without error")
+		return
+	}
+
+	err := reg.ShutdownServer(context.Background(), &http.Server{})
+
+	if err == nil || !strings.HasSuffix(err.Error(), "second shutdown error") {
+		t.Errorf("Registry.ShutdownServer wrong error: %v", err)
+	}
+
+	if want := []string{"second", "first"}; !reflect.DeepEqual(log.seq, want) {
+		t.Errorf("Registry.Shutdown erroneous seq: got %v, want %v", log.seq, want)
+	}
+
+	// shut down controllers are forgotten
+	if err = reg.Shutdown(context.Background()); err != nil || len(log.seq) != 2 {
+		t.Errorf("Registry.Shutdown repeated call: err %v, seq %v", err, log.seq)
+	}
+
+	// done ctx
+	if err = AttachController(newRouter(), first, WithRegistry(reg)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err = reg.Shutdown(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Registry.Shutdown with done ctx wrong error: %v", err)
+	}
+
+	// every controller is shut down with done ctx
+	if want := []string{"second", "first", "first"}; !reflect.DeepEqual(log.seq, want) {
+		t.Errorf("Registry.Shutdown with done ctx erroneous seq: got %v, want %v", log.seq, want)
+	}
+}
+
+//
+// go test -count=1 -v -run TestRegistryRoutesSynthesized1
+
+func TestRegistryRoutesSynthesized1(t *testing.T) {
+
+	r := newRouter()
+
+	reg := NewRegistry()
+
+	if err := AttachController(r, &ControllerAllow{}, WithRegistry(reg), WithAutoHead(), WithAutoOptions(), WithMethodNotAllowed()); err != nil {
+		t.Error(err)
+		return
+	}
+
+	declared, synthesized := 0, make(map[string]string)
+
+	for _, ri := range reg.Routes() {
+
+		if !ri.Synthesized {
+			declared++
+			continue
+		}
+
+		if ri.Path != "/allow/item" {
+			t.Errorf("registry synthesized route of wrong path: %+v", ri)
+			continue
+		}
+
+		synthesized[ri.Method] = ri.Action
+	}
+
+	// GetItem, PostItem and ActionAny expanded to all http methods
+	if want := 2 + len(anyHttpMethods); declared != want {
+		t.Errorf("registry declared routes count mismatch: want %d, got %d", want, declared)
+	}
+
+	for _, c := range []struct{ method, action string }{
+		{http.MethodHead, "GetItem"},
+		{http.MethodOptions, "Options"},
+		{http.MethodDelete, "MethodNotAllowed"},
+		{http.MethodGet, ""},
+		{http.MethodPost, ""},
+	} {
+		if synthesized[c.method] != c.action {
+			t.Errorf("registry synthesized %s route action mismatch: want %q, got %q", c.method, c.action, synthesized[c.method])
+		}
+	}
+}
+
+//
+// go test -count=1 -v -run TestRegistryDefault1
+
+func TestRegistryDefault1(t *testing.T) {
+
+	count := func(reg *Registry) int {
+		reg.mu.Lock()
+		defer reg.mu.Unlock()
+		return len(reg.controllers)
+	}
+
+	r := newRouter()
+
+	n := count(DefaultRegistry)
+
+	log := &testShutdownLog{}
+
+	for _, c := range []interface{}{&ControllerCommon{t: t, quiet: true}, &ControllerShutdownFirst{log}} {
+		if err := AttachController(r, c); err != nil {
+			t.Error(err)
+			return
+		}
+	}
+
+	// routes are never collected without WithRegistry, only controllers to shut down are
+	if routes := DefaultRegistry.Routes(); len(routes) != 0 {
+		t.Errorf("DefaultRegistry collects routes: %v", routes)
+	}
+
+	if got := count(DefaultRegistry) - n; got != 1 {
+		t.Errorf("DefaultRegistry controllers count mismatch: want 1 more, got %d more", got)
+	}
+
+	reg := NewRegistry()
+
+	if err := AttachController(newRouter(), &ControllerCommon{t: t, quiet: true}, WithRegistry(reg)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got := count(reg); got != 0 {
+		t.Errorf("Registry keeps controllers without Shutdown method: %d", got)
+	}
+}
@@ -0,0 +1,65 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"net/http"
+)
+
+// ResourceIDParam is the path param name of the resource member routes SEE WithResource
+const ResourceIDParam = "id"
+
+type resourceRoute struct {
+	method, path string
+}
+
+// resourceRoutes are the standard REST routes of the resource controller methods relative to the controller endpoint
+var resourceRoutes = map[string]resourceRoute{
+	"Index":   {http.MethodGet, ""},
+	"New":     {http.MethodGet, "new"},
+	"Create":  {http.MethodPost, ""},
+	"Show":    {http.MethodGet, ":" + ResourceIDParam},
+	"Edit":    {http.MethodGet, ":" + ResourceIDParam + "/edit"},
+	"Update":  {http.MethodPatch, ":" + ResourceIDParam},
+	"Replace": {http.MethodPut, ":" + ResourceIDParam},
+	"Destroy": {http.MethodDelete, ":" + ResourceIDParam},
+}
+
+// AttachResource is AttachController with WithResource option
+func AttachResource(rg RouterGroup, instance interface{}, opts ...Option) error {
+	return registerController(rg, instance, true, append(opts[:len(opts):len(opts)], WithResource())...)
+}
+
+// WithResource enables resource mode of the registration, where the controller methods
+//
+//	Index   => GET    /
+//	New     => GET    /new
+//	Create  => POST   /
+//	Show    => GET    /:id
+//	Edit    => GET    /:id/edit
+//	Update  => PATCH  /:id
+//	Replace => PUT    /:id
+//	Destroy => DELETE /:id
+//
+// are registered relative to the controller endpoint in addition to the common `<Verb><Endpoint>` methods
+func WithResource() Option {
+	return func(o *options) {
+		o.resource = true
+	}
+}
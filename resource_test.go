@@ -0,0 +1,102 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"testing"
+)
+
+type ControllerArticles struct{}
+
+func (c *ControllerArticles) Index(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "index")
+}
+
+func (c *ControllerArticles) New(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "new")
+}
+
+func (c *ControllerArticles) Create(ctx *gin.Context) {
+	ctx.String(http.StatusCreated, "create")
+}
+
+func (c *ControllerArticles) Show(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "show "+ctx.Param(ResourceIDParam))
+}
+
+func (c *ControllerArticles) Edit(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "edit "+ctx.Param(ResourceIDParam))
+}
+
+func (c *ControllerArticles) Update(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "update "+ctx.Param(ResourceIDParam))
+}
+
+func (c *ControllerArticles) Replace(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "replace "+ctx.Param(ResourceIDParam))
+}
+
+func (c *ControllerArticles) Destroy(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "destroy "+ctx.Param(ResourceIDParam))
+}
+
+func (c *ControllerArticles) GetFeed(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "feed")
+}
+
+var (
+	testControllerArticles1Values = []*testCase{
+		{http.MethodGet, "/articles/", http.StatusOK, "index"},
+		{http.MethodGet, "/articles/new", http.StatusOK, "new"},
+		{http.MethodPost, "/articles/", http.StatusCreated, "create"},
+		{http.MethodGet, "/articles/7", http.StatusOK, "show 7"},
+		{http.MethodGet, "/articles/7/edit", http.StatusOK, "edit 7"},
+		{http.MethodPatch, "/articles/7", http.StatusOK, "update 7"},
+		{http.MethodPut, "/articles/7", http.StatusOK, "replace 7"},
+		{http.MethodDelete, "/articles/7", http.StatusOK, "destroy 7"},
+		{http.MethodGet, "/articles/feed", http.StatusOK, "feed"},
+		//
+		{http.MethodGet, "/plain/articles/feed", http.StatusOK, "feed"},
+		{http.MethodGet, "/plain/articles/", http.StatusNotFound, errStr404},
+		{http.MethodGet, "/plain/articles/7", http.StatusNotFound, errStr404},
+	}
+)
+
+//
+// go test -count=1 -v -run TestAttachResource1
+
+func TestAttachResource1(t *testing.T) {
+
+	r := newRouter()
+
+	if err := AttachResource(r, &ControllerArticles{}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	// resource methods are not routes without resource mode
+	if err := AttachController(r.Group("plain"), &ControllerArticles{}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	helperRunTestsForRouter(t, r, testControllerArticles1Values)
+}
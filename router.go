@@ -22,80 +22,221 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stoewer/go-strcase"
 
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"unsafe"
 )
 
 const (
+	// ControllerPrefix is the default prefix stripped from controller type names SEE SetControllerAffixes
 	ControllerPrefix = "Controller"
 
 	MethodActionPrefix    = "Action"
 	methodActionPrefixLen = len(MethodActionPrefix)
 
 	methodActionNotch = "*"
-)
 
-/*
-type RouterGroup interface {
-	Handle(httpMethod, relativePath string, handlers ...gin.HandlerFunc) gin.IRoutes
-	Any(relativePath string, handlers ...gin.HandlerFunc) gin.IRoutes
-}
-*/
+	// controller method of `Action` methods verbs SEE ControllerActionMethodsMethod
+	actionMethodsMethod = "ActionMethods"
+
+	// multi-verb method names connector, e.g. GetOrPostLogin
+	verbConnector = "Or"
+
+	tagExclude          = "exclude"
+	tagExcludeSeparator = "|"
+
+	// endpoint path params grammar, SEE decodeEndpoint
+	EndpointParamWord    = "By"
+	EndpointCatchAllWord = "CatchAll"
+
+	endpointParamSeparator = '_'
+	endpointCatchAllParam  = "rest"
+)
 
 type (
+	// RouterGroup is any gin router, plain gin.IRoutes is accepted for backward compatibility,
+	// but only BasePathRouterGroup (e.g. *gin.Engine or *gin.RouterGroup) provides absolute paths
 	RouterGroup = gin.IRoutes
 
-	HandlerFunc          = func(ctx *gin.Context)
-	ControllerInitMethod = func() error
-
-	/* TODO
-	// can't use IRoutes because of BasePath absence
-	RouterGroup interface {
-		Handle(string, string, ...gin.HandlerFunc) gin.IRoutes
-		Any(string, ...gin.HandlerFunc) gin.IRoutes
+	// can't use IRoutes only because of BasePath absence
+	BasePathRouterGroup interface {
+		gin.IRoutes
 
 		BasePath() string
 	}
 
-	ControllerInitMethod = func(absPath string) error
-	*/
+	HandlerFunc          = func(ctx *gin.Context)
+	ActionHookFunc       = func(ctx *gin.Context, action string)
+	AroundHookFunc       = func(ctx *gin.Context, next func())
+	ControllerInitMethod = func() error
+
+	// ControllerInitAbsPathMethod is Init method signature, which receives absolute mount path of the controller
+	// (base path of the router group + controller segment or prefix), for plain gin.IRoutes base path is "/"
+	ControllerInitAbsPathMethod = func(absPath string) error
+
+	// ControllerActionMethodsMethod is ActionMethods method signature, which returns http methods of `Action` methods
+	// instead of all ones SEE WithActionMethods
+	ControllerActionMethodsMethod = func() []string
 )
 
 var (
-	// RFC7231 Section 4.3
+	// RFC7231 Section 4.3 + RFC5789 PATCH
 	// ATN! array, not slice!
 	rfcHttpMethods = [...]string{
 		http.MethodGet, http.MethodPost, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodConnect, http.MethodTrace,
+		http.MethodPatch,
+	}
+
+	// RFC4918 Section 9
+	WebDAVHttpMethods = [...]string{
+		"PROPFIND", "PROPPATCH", "MKCOL", "COPY", "MOVE", "LOCK", "UNLOCK",
 	}
 
-	appendTrailingSlash = false
+	// rfcHttpMethods + all registered by RegisterHttpMethod, sorted by length desc for the longest prefix match
+	// ATN! copy on write, never modify in place
+	httpMethods   = sortHttpMethods(rfcHttpMethods[:])
+	httpMethodsMu sync.RWMutex
 )
 
-func AppendTrailingSlash(on bool) {
-	appendTrailingSlash = on
+// RegisterHttpMethod adds extension http methods (e.g. WebDAV PROPFIND, MKCOL or custom PURGE) to the set of
+// recognized method name prefixes; method must be non-empty uppercase latin word as gin requires
+func RegisterHttpMethod(methods ...string) error {
+
+	httpMethodsMu.Lock()
+	defer httpMethodsMu.Unlock()
+
+	list := append([]string(nil), httpMethods...)
+
+	for _, m := range methods {
+
+		if !isValidHttpMethod(m) {
+			return fmt.Errorf("ginext.RegisterHttpMethod error: wrong http method %q", m)
+		}
+
+		if !containsString(list, m) {
+			list = append(list, m)
+		}
+	}
+
+	httpMethods = sortHttpMethods(list)
+
+	return nil
 }
 
-func AttachController(rg RouterGroup, instance interface{}) error {
-	return registerController(rg, instance, true)
+// HttpMethods returns all recognized http methods
+func HttpMethods() []string {
+
+	httpMethodsMu.RLock()
+	defer httpMethodsMu.RUnlock()
+
+	return append([]string(nil), httpMethods...)
 }
 
-func EmbedController(rg RouterGroup, instance interface{}) error {
-	return registerController(rg, instance, false)
+func sortHttpMethods(list []string) []string {
+
+	list = append([]string(nil), list...)
+
+	// stable to keep RFC order for the same length methods
+	sort.SliceStable(list, func(i, j int) bool {
+		return len(list[i]) > len(list[j])
+	})
+
+	return list
+}
+
+// gin requires http method to match ^[A-Z]+$
+func isValidHttpMethod(m string) bool {
+
+	if m == "" {
+		return false
+	}
+
+	for i := 0; i < len(m); i++ {
+		if c := m[i]; c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func containsString(list []string, s string) bool {
+
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
+
+func AttachController(rg RouterGroup, instance interface{}, opts ...Option) error {
+	return registerController(rg, instance, true, opts...)
+}
+
+func EmbedController(rg RouterGroup, instance interface{}, opts ...Option) error {
+	return registerController(rg, instance, false, opts...)
 }
 
 const (
 	errRegisterControllerPrefix = "ginext.registerController error: "
 )
 
-func registerController(rg RouterGroup, instance interface{}, prependControllerEndpoint bool) (err error) {
+// registerController validates controller and computes the whole routes plan first, then checks the plan against
+// gin router restrictions (dry run), calls Init and only then adds the routes to rg,
+// so any error leaves rg untouched
+func registerController(rg RouterGroup, instance interface{}, prependControllerEndpoint bool, opts ...Option) (err error) {
+
+	p, err := planController(rg, instance, prependControllerEndpoint, newOptions(opts), nil)
+
+	if err != nil {
+		return err
+	}
+
+	if err = p.dryRun(rg); err != nil {
+		return err
+	}
+
+	if err = p.setup(); err != nil {
+		return err
+	}
+
+	if err = p.commit(rg); err != nil {
+		return err
+	}
+
+	// ATN! routes are collected only on demand, so registrations without registry never pile them up
+	if p.registry != nil {
+		p.registry.add(p.controllers(), p.routesInfo(rg))
+	} else {
+		DefaultRegistry.add(p.controllers(), nil)
+	}
+
+	return nil
+}
+
+// planController computes routes plan of the controller instance and its sub-controllers,
+// parent is the wrap of the parent controller for sub-controller or nil
+func planController(rg RouterGroup, instance interface{}, prependControllerEndpoint bool, o *options, parent *controllerWrap) (p *controllerPlan, err error) {
+
+	methods, err := o.httpMethodsList()
+
+	if err != nil {
+		return nil, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T: %w", instance, err)
+	}
 
 	v := reflect.ValueOf(instance)
 	k := v.Kind()
 
 	if k != reflect.Ptr && k != reflect.Interface {
-		return fmt.Errorf(errRegisterControllerPrefix+"wrong instance type: %[1]T (value %[1]v)", instance)
+		return nil, fmt.Errorf(errRegisterControllerPrefix+"wrong instance type: %[1]T (value %[1]v)", instance)
 	}
 
 	t, e := v.Type(), v.Elem()
@@ -106,155 +247,833 @@ func registerController(rg RouterGroup, instance interface{}, prependControllerE
 	n := t.NumMethod()
 
 	if n == 0 {
-		return fmt.Errorf(errRegisterControllerPrefix+"controller instance %v (%[1]T): methods not found", instance)
+		return nil, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v (%[1]T): methods not found", instance)
+	}
+
+	// ATN! SetContext of shared Controller would mix contexts of concurrent requests
+	if _, ok := instance.(contextSetter); ok && e.Kind() == reflect.Struct && embedsControllerByPointer(e.Type()) {
+		return nil, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T embeds ginext.Controller by pointer, embed it by value", instance)
+	}
+
+	p = &controllerPlan{
+		instance: instance,
+		deps:     o.deps,
+		engine:   o.engine,
+		registry: o.registry,
+	}
+
+	if p.engine == nil {
+		p.engine = routerEngine(rg)
 	}
 
 	controllerName := e.Type().Name()
 
 	var controllerEndpoint string
 
-	if prependControllerEndpoint {
-		if controllerEndpoint = strings.TrimPrefix(controllerName, ControllerPrefix); controllerEndpoint != "" {
-			controllerEndpoint = strcase.KebabCase(controllerEndpoint)
+	if o.hasPrefix {
+		controllerEndpoint, prependControllerEndpoint = strings.Trim(o.prefix, "/"), true
+	} else if prependControllerEndpoint {
+		if controllerEndpoint, _ = o.affixes.trim(controllerName); controllerEndpoint != "" {
+			controllerEndpoint = o.naming(controllerEndpoint)
 		}
 	}
 
+	p.absPath = joinPaths(basePath(rg), controllerEndpoint)
+
 	// Init, Before, After
 	methodValue := v.MethodByName("Init")
 
 	// SEE https://github.com/golang/go/issues/46320#issuecomment-1081940201
 	if methodValue.IsValid() && !methodValue.IsNil() {
 
-		// TODO absPath
-		//absPath := joinPaths(rg.BasePath, controllerEndpoint)
+		switch initMethod := methodValue.Interface().(type) {
+		case ControllerInitMethod:
+			p.init = func(string) error {
+				return initMethod()
+			}
+		case ControllerInitAbsPathMethod:
+			p.init = initMethod
+		case ControllerInitDepsMethod:
 
-		initInstance := methodValue.Interface()
-		initMethod, ok := initInstance.(ControllerInitMethod)
+			deps := o.deps
 
-		if !ok {
-			return fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has Init method with wrong signature %T", instance, initInstance)
-		}
+			if deps == nil {
+				deps = NewDeps()
+			}
 
-		// early call before method registration
-		if err = initMethod(); err != nil {
-			return fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T Init err: %w", instance, err)
+			p.init = func(string) error {
+				return initMethod(deps)
+			}
+		default:
+			return nil, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has Init method with wrong signature %T", instance, initMethod)
 		}
 	}
 
-	// equivalent of struct { Before, handler, After } as an array,
-	// possibleHandlers[0] = Before
-	// possibleHandlers[1] = methodHandler
-	// possibleHandlers[2] = After
+	// Shutdown
+
+	if p.shutdown, err = extractShutdownMethod(instance, &v); err != nil {
+		return nil, err
+	}
+
+	// receiver of all the controller methods below, which is either the instance itself or per-request one
+	recv := newControllerReceiver(v, o)
+
+	// Before, After and their action scoped variants BeforeAction, AfterAction
+	// NOTE methods are referenced by index in the method set of instance type and bound to the receiver
+	// NOTE Before and After are composed from all the levels of the controller embedding tree SEE WithChainedWrappers
 	var (
-		possibleHandlers [3]gin.HandlerFunc
-		actualHandlers   = possibleHandlers[:]
+		before, after             []wrapperMethod
+		beforeAction, afterAction int
 	)
 
-	// Before
+	if before, err = extractWrapperMethods(instance, &v, "Before", o.chainedWrappers); err != nil {
+		return nil, err
+	}
 
-	if possibleHandlers[0], err = extractWrapperMethod(instance, &v, "Before"); err != nil {
-		return err
+	if after, err = extractWrapperMethods(instance, &v, "After", o.chainedWrappers); err != nil {
+		return nil, err
 	}
 
-	// shift actualHandlers if no Before
-	if possibleHandlers[0] == nil {
-		actualHandlers = actualHandlers[1:]
+	if beforeAction, err = extractActionHookMethod(instance, &v, "BeforeAction"); err != nil {
+		return nil, err
 	}
 
-	// After
+	if afterAction, err = extractActionHookMethod(instance, &v, "AfterAction"); err != nil {
+		return nil, err
+	}
 
-	if possibleHandlers[2], err = extractWrapperMethod(instance, &v, "After"); err != nil {
-		return err
+	// Around
+
+	around, err := extractAroundMethod(instance, &v)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Finally
+
+	finally, err := extractWrapperMethod(instance, &v, "Finally")
+
+	if err != nil {
+		return nil, err
+	}
+
+	// HandleError
+
+	onErrorIndex, err := extractErrorHandlerMethod(instance, &v)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Routes
+
+	overrides, err := extractRoutesMethod(instance, &v)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// ActionMethods
+
+	actionMethods, err := extractActionMethods(instance, &v, o)
+
+	if err != nil {
+		return nil, err
+	}
+
+	excluded := excludedMethods(t, o)
+
+	onError := o.errorHandler
+
+	if onErrorIndex >= 0 {
+		onError = recv.bindErrorHandler(onErrorIndex)
 	}
 
-	// shift actualHandlers if no After
-	if possibleHandlers[2] == nil {
-		actualHandlers = actualHandlers[:len(actualHandlers)-1]
+	// outer prefix is { [per-request instance], Finally, middleware..., Before } and outer suffix is { After },
+	// sub-controllers inherit them SEE controllerWrap
+	wrap := wrapController(parent, instance)
+
+	wrap.prefix.add(recv.instantiate(), "Instantiate")
+	wrap.prefix.add(recv.bind(finally, finallyConv), "Finally")
+
+	for _, mw := range o.middleware {
+		wrap.prefix.add(mw, nameOfFunction(mw))
 	}
 
-	// now actualHandlers slices non-nil wrapper methods + handler (possibleHandlers[1])
+	// embedded (base) Before first, embedded After last
+	for _, w := range before {
+		wrap.prefix.add(recv.bindField(w.path, w.index, handlerConv), w.name)
+	}
+
+	for i := len(after) - 1; i >= 0; i-- {
+		wrap.suffix.add(recv.bindField(after[i].path, after[i].index, handlerConv), after[i].name)
+	}
+
+	if parent != nil {
+		wrap.suffix.concat(&parent.suffix)
+	}
 
 	for i := 0; i < n; i++ {
 
 		mi := t.Method(i)
 		name := mi.Name
 
-		if m, e := decodeControllerMethod(name); m != "" {
+		if name == routesMethod || name == actionMethodsMethod || excluded[name] {
+			continue
+		}
+
+		var (
+			ms  []string
+			e   string
+			err error
+		)
+
+		if rr, ok := resourceRoutes[name]; ok && o.resource {
+			ms, e = []string{rr.method}, rr.path
+		} else {
+			ms, e, err = decodeControllerMethod(name, methods, o)
+		}
+
+		override, overridden := overrides[name]
+
+		// NOTE wrong name does not matter if path is overridden
+		if err != nil && (!overridden || override.Path == "") {
+			return nil, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has action method %v with wrong name: %w", instance, name, err)
+		}
+
+		if overridden {
 
-			if prependControllerEndpoint {
-				e = controllerEndpoint + "/" + e
+			var m string
+
+			if len(ms) != 0 {
+				m = ms[0]
 			}
 
-			if appendTrailingSlash && e[len(e)-1] != '/' { // avoid double trailing slash
-				e = e + "/"
+			if m, e, err = override.override(name, m, e, o.naming); err != nil {
+				return nil, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T: %w", instance, err)
 			}
 
-			// method's instance with stored receiver
-			methodInstance := v.Method(i).Interface()
+			// overridden http method replaces all the verbs of the name
+			if override.Method != "" || len(ms) == 0 {
+				ms = []string{m}
+			}
+		}
 
-			var ok bool
+		if len(ms) == 0 {
+			continue
+		}
 
-			// ATN! not gin.HandlerFunc :: panic: interface conversion: interface {} is func(*gin.Context), not gin.HandlerFunc [recovered]
-			possibleHandlers[1], ok = methodInstance.(HandlerFunc)
+		// NOTE `Action` method verb is never combined with the others
+		if actionMethods != nil && ms[0] == methodActionNotch {
+			ms = actionMethods
+		}
 
-			if !ok {
-				return fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has action method %v with wrong signature %T", instance, name, methodInstance)
+		if strings.HasPrefix(override.Path, "/") {
+			// overridden path is relative to the router group
+			e = strings.TrimPrefix(e, "/")
+		} else if prependControllerEndpoint && controllerEndpoint != "" {
+			e = controllerEndpoint + "/" + e
+		}
+
+		// NOTE catch-all param must be the last segment of the path, so never append slash after it,
+		// and overridden path is used as is
+		if o.appendTrailingSlash && override.Path == "" && e != "" && e[len(e)-1] != '/' && !isCatchAllEndpoint(e) { // avoid double trailing slash
+			e = e + "/"
+		}
+
+		adapter := newActionAdapter(v.Method(i).Type())
+
+		if adapter == nil {
+			return nil, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has action method %v with wrong signature %T", instance, name, v.Method(i).Interface())
+		}
+
+		// per action Before<Name>, After<Name>
+		// NOTE `Action` method hooks are the same as BeforeAction, AfterAction, so skip them if they are action scoped
+		beforeName, afterName := -1, -1
+
+		if name != MethodActionPrefix || beforeAction < 0 {
+			if beforeName, err = extractWrapperMethod(instance, &v, "Before"+name); err != nil {
+				return nil, err
 			}
+		}
 
-			if m == methodActionNotch {
-				rg.Any(e, actualHandlers...)
-			} else {
-				rg.Handle(m, e, actualHandlers...)
+		if name != MethodActionPrefix || afterAction < 0 {
+			if afterName, err = extractWrapperMethod(instance, &v, "After"+name); err != nil {
+				return nil, err
 			}
 		}
+
+		// action scoped chain is { BeforeAction, Before<Name>, handler, After<Name>, AfterAction },
+		// where all except handler are optional
+		var actionChain handlersChain
+
+		actionChain.add(recv.bind(beforeAction, actionHookConv(name)), "BeforeAction")
+		actionChain.add(recv.bind(beforeName, handlerConv), "Before"+name)
+		actionChain.add(recv.bind(i, actionConv(adapter, onError)), name)
+		actionChain.add(recv.bind(afterName, handlerConv), "After"+name)
+		actionChain.add(recv.bind(afterAction, actionHookConv(name)), "AfterAction")
+
+		// chain is { parent prefix..., outer prefix..., route middleware..., Around { action scoped chain... }, outer suffix..., parent suffix... },
+		// where all except action scoped chain are optional
+		var chain handlersChain
+
+		chain.concat(&wrap.prefix)
+
+		for _, mw := range override.Middleware {
+			chain.add(mw, nameOfFunction(mw))
+		}
+
+		if around >= 0 {
+			chain.add(recv.bind(around, aroundConv(&actionChain)), "Around["+strings.Join(actionChain.names, " ")+"]")
+		} else {
+			chain.concat(&actionChain)
+		}
+
+		chain.concat(&wrap.suffix)
+
+		// NOTE multi-verb method has the same chain for every verb
+		for _, m := range ms {
+			p.routes = append(p.routes, controllerRoute{
+				method:     m,
+				path:       e,
+				name:       name,
+				controller: t,
+				chain:      chain,
+			})
+		}
 	}
 
-	return nil
+	// sub-controllers mounted from struct fields
+
+	if err = p.planSubControllers(rg, &v, controllerEndpoint, o, wrap); err != nil {
+		return nil, err
+	}
+
+	// synthesized routes of all the paths of the controller and its sub-controllers
+
+	// NOTE allow routes take into account head ones
+	if parent == nil {
+		p.routes = append(p.routes, p.headRoutes(o)...)
+		p.routes = append(p.routes, p.allowRoutes(o)...)
+	}
+
+	return p, nil
+}
+
+// lookupMethod returns index and bound value of the public method of v or -1 if there is no such method
+func lookupMethod(v *reflect.Value, method string) (int, interface{}) {
+
+	mi, ok := v.Type().MethodByName(method)
+
+	if !ok {
+		return -1, nil
+	}
+
+	return mi.Index, v.Method(mi.Index).Interface()
 }
 
-func extractWrapperMethod(instance interface{}, v *reflect.Value, method string) (gin.HandlerFunc, error) {
+// extractActionMethods returns http methods of `Action` methods from `ActionMethods` controller method or
+// WithActionMethods option, or nil for all http methods
+func extractActionMethods(instance interface{}, v *reflect.Value, o *options) ([]string, error) {
 
-	methodValue := v.MethodByName(method)
+	methods, src := o.actionMethods, "WithActionMethods option"
+
+	methodValue := v.MethodByName(actionMethodsMethod)
 
 	// SEE https://github.com/golang/go/issues/46320#issuecomment-1081940201
 	if methodValue.IsValid() && !methodValue.IsNil() {
 
-		methodInstance := methodValue.Interface()
+		actionMethodsFunc, ok := methodValue.Interface().(ControllerActionMethodsMethod)
+
+		if !ok {
+			return nil, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has %s method with wrong signature %T", instance, actionMethodsMethod, methodValue.Interface())
+		}
+
+		methods, src = actionMethodsFunc(), actionMethodsMethod+" method"
 
-		// ATN! not gin.HandlerFunc :: panic: interface conversion: interface {} is func(*gin.Context), not gin.HandlerFunc [recovered]
-		if wrapperMethod, ok := methodInstance.(HandlerFunc); ok {
-			return wrapperMethod, nil
+		if methods == nil {
+			methods = []string{}
 		}
+	}
 
-		// !ok => error wrong type
-		return nil, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has wrapper method %v with wrong signature %T", instance, method, methodInstance)
+	if methods == nil {
+		return nil, nil
 	}
 
-	return nil, nil
+	if len(methods) == 0 {
+		return nil, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has empty http methods set of %s", instance, src)
+	}
+
+	for _, m := range methods {
+		if !isValidHttpMethod(m) {
+			return nil, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has wrong http method %q in %s", instance, m, src)
+		}
+	}
+
+	return append([]string(nil), methods...), nil
 }
 
-func decodeControllerMethod(method string) (m, e string) {
+// extractActionHookMethod returns index of action scoped hook method or -1 if there is no such method
+// or it has gin.HandlerFunc signature (hook of the `Action` method itself)
+func extractActionHookMethod(instance interface{}, v *reflect.Value, method string) (int, error) {
+
+	index, methodInstance := lookupMethod(v, method)
 
-	if strings.HasPrefix(method, MethodActionPrefix) {
-		return methodActionNotch, strcase.KebabCase(method[methodActionPrefixLen:])
+	if index < 0 {
+		return -1, nil
 	}
 
-	if m = httpMethod(method); m == "" {
-		return "", ""
+	switch methodInstance.(type) {
+	case ActionHookFunc:
+		return index, nil
+	case HandlerFunc:
+		return -1, nil
+	default:
+		return -1, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has action hook method %v with wrong signature %T", instance, method, methodInstance)
 	}
+}
+
+func extractAroundMethod(instance interface{}, v *reflect.Value) (int, error) {
+
+	index, methodInstance := lookupMethod(v, "Around")
 
-	return m, strcase.KebabCase(method[len(m):])
+	if index < 0 {
+		return -1, nil
+	}
+
+	if _, ok := methodInstance.(AroundHookFunc); ok {
+		return index, nil
+	}
+
+	return -1, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has Around method with wrong signature %T", instance, methodInstance)
 }
 
-func httpMethod(s string) string {
+// deferHandler converts handler h to the one, which runs the rest of the chain and then h with defer semantics,
+// so h runs even if the rest of the chain aborts or panics
+func deferHandler(h gin.HandlerFunc) gin.HandlerFunc {
+
+	if h == nil {
+		return nil
+	}
+
+	return func(ctx *gin.Context) {
+		defer h(ctx)
+		ctx.Next()
+	}
+}
+
+// extractWrapperMethod returns index of gin.HandlerFunc method or -1 if there is no such method
+func extractWrapperMethod(instance interface{}, v *reflect.Value, method string) (int, error) {
+
+	index, methodInstance := lookupMethod(v, method)
+
+	if index < 0 {
+		return -1, nil
+	}
+
+	// ATN! not gin.HandlerFunc :: panic: interface conversion: interface {} is func(*gin.Context), not gin.HandlerFunc [recovered]
+	if _, ok := methodInstance.(HandlerFunc); ok {
+		return index, nil
+	}
+
+	// !ok => error wrong type
+	return -1, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has wrapper method %v with wrong signature %T", instance, method, methodInstance)
+}
+
+// wrapperMethod is wrapper method declared at some level of the controller embedding tree
+type wrapperMethod struct {
+	path  []int  // index sequence of the embedded field, nil for the controller itself
+	index int    // index in the method set of the level
+	name  string // handler name
+}
+
+// WithChainedWrappers composes Before and After from all levels of the controller embedding tree instead of the single
+// (overriding or promoted) one: embedded levels Before run first, their After run last
+// ATN! base wrapper explicitly called by the overriding one runs twice in this mode
+func WithChainedWrappers() Option {
+	return func(o *options) {
+		o.chainedWrappers = true
+	}
+}
+
+// extractWrapperMethods returns the controller wrapper method or, if chained, wrapper methods declared at all levels
+// of the controller embedding tree, embedded levels first in depth-first fields order, so the base wrappers
+// are followed by overriding ones
+// NOTE method promoted through embedded interface is used as is
+func extractWrapperMethods(instance interface{}, v *reflect.Value, method string, chained bool) (ws []wrapperMethod, err error) {
+
+	if chained {
+		if ws, err = collectWrapperMethods(instance, *v, nil, method, method); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(ws) == 0 {
+
+		index, err := extractWrapperMethod(instance, v, method)
+
+		if err != nil || index < 0 {
+			return nil, err
+		}
+
+		ws = append(ws, wrapperMethod{index: index, name: method})
+	}
+
+	return ws, nil
+}
+
+func collectWrapperMethods(instance interface{}, v reflect.Value, path []int, method, name string) (ws []wrapperMethod, err error) {
+
+	if e := v.Elem(); e.Kind() == reflect.Struct {
+
+		st := e.Type()
+
+		for i, n := 0, st.NumField(); i < n; i++ {
+
+			sf := st.Field(i)
+
+			if !sf.Anonymous {
+				continue
+			}
+
+			fv := embeddedReceiver(e.Field(i))
+
+			if !fv.IsValid() {
+				continue
+			}
+
+			fws, err := collectWrapperMethods(instance, fv, append(path[:len(path):len(path)], i), method, fv.Type().Elem().Name()+"."+method)
+
+			if err != nil {
+				return nil, err
+			}
+
+			ws = append(ws, fws...)
+		}
+	}
+
+	if !isDeclaredMethod(v.Type(), method) {
+		return ws, nil
+	}
+
+	index, err := extractWrapperMethod(instance, &v, method)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return append(ws, wrapperMethod{path, index, name}), nil
+}
+
+// embeddedReceiver returns pointer receiver of the embedded struct field or invalid value for the other or nil fields
+// NOTE fields of unexported embedded types are accessed unsafely, as their promoted methods are callable anyway
+func embeddedReceiver(fv reflect.Value) reflect.Value {
+
+	if !fv.CanInterface() {
+		fv = reflect.NewAt(fv.Type(), unsafe.Pointer(fv.UnsafeAddr())).Elem()
+	}
+
+	switch fv.Kind() {
+	case reflect.Struct:
+		return fv.Addr()
+	case reflect.Ptr:
+		if !fv.IsNil() && fv.Elem().Kind() == reflect.Struct {
+			return fv
+		}
+	}
+
+	return reflect.Value{}
+}
+
+// isDeclaredMethod reports whether method of the pointer type t is declared by t or its element type itself,
+// but not promoted from embedded field
+// NOTE promoted methods (and methods of the element type in the pointer method set) are autogenerated wrappers
+func isDeclaredMethod(t reflect.Type, method string) bool {
+
+	for _, t := range [...]reflect.Type{t, t.Elem()} {
+		if mi, ok := t.MethodByName(method); ok && !isAutogeneratedFunc(mi.Func) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isAutogeneratedFunc(fn reflect.Value) bool {
+
+	f := runtime.FuncForPC(fn.Pointer())
+
+	if f == nil {
+		return false
+	}
+
+	file, _ := f.FileLine(f.Entry())
+
+	return file == "<autogenerated>"
+}
+
+// decodeControllerMethod returns http methods (or methodActionNotch) and endpoint of the action method
+// or empty ms for non-action method; in strict mode verb must be title-cased and followed by word boundary SEE WithStrictVerbs;
+// the first verb may be followed by the other title-cased ones connected by `Or` word or directly SEE WithConcatVerbs
+func decodeControllerMethod(method string, methods []string, o *options) (ms []string, e string, err error) {
+
+	if strings.HasPrefix(method, MethodActionPrefix) && (!o.strictVerbs || isWordBoundary(method, methodActionPrefixLen)) {
+		ms, method = []string{methodActionNotch}, method[methodActionPrefixLen:]
+	} else if m := httpMethod(method, methods, o.strictVerbs); m != "" {
+
+		ms, method = []string{m}, method[len(m):]
+
+		for {
+
+			if m, method = nextVerb(method, methods, o.concatVerbs); m == "" {
+				break
+			}
+
+			if containsString(ms, m) {
+				return nil, "", fmt.Errorf("duplicate verb %s", m)
+			}
+
+			ms = append(ms, m)
+		}
+	} else {
+		return nil, "", nil
+	}
+
+	if e, err = decodeEndpoint(method, o.naming); err != nil {
+		return nil, "", err
+	}
+
+	return ms, e, nil
+}
+
+// nextVerb returns the next title-cased verb of the multi-verb method name connected to the previous one
+// by `Or` word (or directly if concat is on) and the rest of the name, or empty verb and the name as is
+func nextVerb(s string, methods []string, concat bool) (string, string) {
+
+	if strings.HasPrefix(s, verbConnector) {
+		if m := strictHttpMethod(s[len(verbConnector):], methods); m != "" {
+			return m, s[len(verbConnector)+len(m):]
+		}
+	}
+
+	if concat {
+		if m := strictHttpMethod(s, methods); m != "" {
+			return m, s[len(m):]
+		}
+	}
+
+	return "", s
+}
+
+// decodeEndpoint converts CamelCase endpoint part of method name to the gin router path, where
+//
+//	`By<Name>` or `By_<Name>` word is converted to the param segment `/:name`
+//	`CatchAll<Name>` or `CatchAll_<Name>` word is converted to the catch-all segment `/*name`
+//	(bare `CatchAll` word converted to `/*rest`)
+//
+// param name continues up to the next `_` or to the end of endpoint and converted to snake_case,
+// the rest words are converted to path segments by naming strategy (kebab-case by default), e.g.
+//
+//	UserByID                  => user/:id
+//	UserBy_ID                 => user/:id
+//	PostByPostID_Comments     => post/:post_id/comments
+//	FilesCatchAllPath         => files/*path
+//	StaticCatchAll            => static/*rest
+//
+// keyword is recognized only if it is followed by uppercase letter, digit or `_` (or end of endpoint for the catch-all),
+// so words like `Bypass` or `Byte` remain static
+func decodeEndpoint(s string, naming NamingStrategy) (string, error) {
+
+	segments := make([]string, 0, 2)
+
+	for s != "" {
+
+		i, word := nextEndpointParamWord(s)
+
+		if i < 0 {
+			segments = append(segments, naming(s))
+			break
+		}
+
+		if static := strings.Trim(s[:i], string(endpointParamSeparator)); static != "" {
+			segments = append(segments, naming(static))
+		}
+
+		s = s[i+len(word):]
+
+		// explicit `By_<Name>` form
+		if s != "" && s[0] == endpointParamSeparator {
+			s = s[1:]
+		}
+
+		j := strings.IndexByte(s, endpointParamSeparator)
+
+		if j < 0 {
+			j = len(s)
+		}
+
+		name := s[:j]
+
+		s = strings.TrimLeft(s[j:], string(endpointParamSeparator))
+
+		if word == EndpointCatchAllWord {
+
+			if s != "" {
+				return "", fmt.Errorf("catch-all param %q must be the last part of endpoint", name)
+			}
+
+			if name == "" {
+				name = endpointCatchAllParam
+			}
+
+			segments = append(segments, "*"+strcase.SnakeCase(name))
+			break
+		}
+
+		if name == "" {
+			return "", errors.New("empty param name after " + EndpointParamWord)
+		}
+
+		segments = append(segments, ":"+strcase.SnakeCase(name))
+	}
+
+	return strings.Join(segments, "/"), nil
+}
+
+// nextEndpointParamWord returns position and the param keyword of the first one found in s or -1 if there is no keyword
+func nextEndpointParamWord(s string) (int, string) {
+
+	for i := 0; i < len(s); i++ {
+
+		if s[i] != EndpointParamWord[0] && s[i] != EndpointCatchAllWord[0] {
+			continue
+		}
+
+		// ATN! CatchAll first because of the same first letter possibility
+		if isEndpointParamWordAt(s, i, EndpointCatchAllWord, true) {
+			return i, EndpointCatchAllWord
+		}
+
+		if isEndpointParamWordAt(s, i, EndpointParamWord, false) {
+			return i, EndpointParamWord
+		}
+	}
+
+	return -1, ""
+}
+
+func isEndpointParamWordAt(s string, i int, word string, bare bool) bool {
+
+	if !strings.HasPrefix(s[i:], word) {
+		return false
+	}
+
+	if i += len(word); i == len(s) {
+		return bare
+	}
+
+	c := s[i]
+
+	return c == endpointParamSeparator || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+func isCatchAllEndpoint(e string) bool {
+
+	i := strings.LastIndexByte(e, '/')
+
+	return e[i+1:] != "" && e[i+1] == '*'
+}
+
+// httpMethod returns the longest http method of the list which is a prefix of s,
+// list must be sorted by length desc SEE sortHttpMethods
+func httpMethod(s string, list []string, strict bool) string {
+
+	if strict {
+		return strictHttpMethod(s, list)
+	}
 
 	s = strings.ToUpper(s)
 
-	for i := 0; i < len(rfcHttpMethods); i++ {
-		if m := rfcHttpMethods[i]; strings.HasPrefix(s, m) {
+	// the first match is the longest one
+	for i := 0; i < len(list); i++ {
+		if m := list[i]; strings.HasPrefix(s, m) {
 			return m
 		}
 	}
 
 	return ""
 }
+
+// strictHttpMethod returns the longest http method of the list which is title-cased prefix of s followed by
+// word boundary, e.g. GET for `Get`, `GetUser`, `Get2`, `Get_`, but not for `GETUser` or `Getaway`
+func strictHttpMethod(s string, list []string) string {
+
+	for i := 0; i < len(list); i++ {
+		if m := list[i]; len(s) >= len(m) && s[0] == m[0] && s[1:len(m)] == strings.ToLower(m[1:]) && isWordBoundary(s, len(m)) {
+			return m
+		}
+	}
+
+	return ""
+}
+
+// isWordBoundary reports whether the CamelCase word of s ends before i-th byte: it is the end of s or
+// uppercase letter, digit or `_` at i
+func isWordBoundary(s string, i int) bool {
+
+	if i >= len(s) {
+		return true
+	}
+
+	c := s[i]
+
+	return (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == endpointParamSeparator
+}
+
+// excludedMethods returns the set of methods excluded from routes decoding by WithExclude option and
+// `ginext:"exclude=Method1|Method2"` tags of the controller struct fields (including embedded structs)
+func excludedMethods(t reflect.Type, o *options) map[string]bool {
+
+	excluded := make(map[string]bool)
+
+	for _, name := range o.exclude {
+		excluded[name] = true
+	}
+
+	collectExcludedMethods(t, excluded, make(map[reflect.Type]bool))
+
+	return excluded
+}
+
+func collectExcludedMethods(t reflect.Type, excluded map[string]bool, visited map[reflect.Type]bool) {
+
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct || visited[t] {
+		return
+	}
+
+	visited[t] = true
+
+	for i, n := 0, t.NumField(); i < n; i++ {
+
+		sf := t.Field(i)
+
+		opts, _ := tagOptions(sf)
+
+		if list, ok := opts[tagExclude]; ok {
+			for _, name := range strings.Split(list, tagExcludeSeparator) {
+				if name = strings.TrimSpace(name); name != "" {
+					excluded[name] = true
+				}
+			}
+		}
+
+		if sf.Anonymous {
+			collectExcludedMethods(sf.Type, excluded, visited)
+		}
+	}
+}
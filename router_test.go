@@ -21,6 +21,7 @@ package ginext
 import (
 	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/stoewer/go-strcase"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -179,6 +180,54 @@ func (c *ControllerCompound) ActionOverride(ctx *gin.Context) {
 
 // ==============
 
+type ControllerParams struct {
+	t *testing.T
+}
+
+func (c *ControllerParams) GetUserByID(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "ControllerParams.GET:UserByID "+ctx.Param("id"))
+}
+
+func (c *ControllerParams) PutUserBy_ID(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "ControllerParams.PUT:UserBy_ID "+ctx.Param("id"))
+}
+
+func (c *ControllerParams) GetPostByPostID_CommentsByCommentID(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "ControllerParams.GET:PostByPostID_CommentsByCommentID "+ctx.Param("post_id")+" "+ctx.Param("comment_id"))
+}
+
+func (c *ControllerParams) GetFilesCatchAllPath(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "ControllerParams.GET:FilesCatchAllPath "+ctx.Param("path"))
+}
+
+func (c *ControllerParams) GetStaticCatchAll(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "ControllerParams.GET:StaticCatchAll "+ctx.Param("rest"))
+}
+
+func (c *ControllerParams) GetBypass(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "ControllerParams.GET:Bypass")
+}
+
+// ==============
+
+type ControllerVerbs struct {
+	t *testing.T
+}
+
+func (c *ControllerVerbs) PatchUser(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "ControllerVerbs.PATCH:User")
+}
+
+func (c *ControllerVerbs) PurgeCache(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "ControllerVerbs.PURGE:Cache")
+}
+
+func (c *ControllerVerbs) PropfindFile(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "ControllerVerbs.PROPFIND:File")
+}
+
+// ==============
+
 func helperCheckTestCaseResponseResult(t *testing.T, tcase *testCase, rr *httptest.ResponseRecorder) bool {
 
 	r := rr.Result()
@@ -362,6 +411,121 @@ func TestRegisterControllerCompound1(t *testing.T) {
 //
 // ==============
 
+type ControllerAuthBase struct{}
+
+func (c *ControllerAuthBase) Before(ctx *gin.Context) {
+	ctx.Writer.WriteString("base-before ")
+}
+
+func (c *ControllerAuthBase) After(ctx *gin.Context) {
+	ctx.Writer.WriteString("base-after")
+}
+
+type auditBase struct{}
+
+func (c auditBase) Before(ctx *gin.Context) {
+	ctx.Writer.WriteString("audit-before ")
+}
+
+type ControllerLayered struct {
+	ControllerAuthBase
+	auditBase
+}
+
+func (c *ControllerLayered) Before(ctx *gin.Context) {
+	ctx.Writer.WriteString("before ")
+}
+
+func (c *ControllerLayered) After(ctx *gin.Context) {
+	ctx.Writer.WriteString("after ")
+}
+
+func (c *ControllerLayered) Get(ctx *gin.Context) {
+	ctx.Writer.WriteString("get ")
+}
+
+type ControllerLayeredPerRequest struct {
+	*ControllerAuthBase
+	Controller
+}
+
+func (c *ControllerLayeredPerRequest) Get(ctx *gin.Context) {
+	ctx.Writer.WriteString("get ")
+}
+
+var (
+	testControllerLayered1Values = []*testCase{
+		{http.MethodGet, "/layered/", http.StatusOK, "base-before audit-before before get after base-after"},
+		{http.MethodGet, "/layered-per-request/", http.StatusOK, "base-before get base-after"},
+	}
+)
+
+//
+// go test -count=1 -v -run TestRegisterControllerLayered1
+
+func TestRegisterControllerLayered1(t *testing.T) {
+
+	r := newRouter()
+
+	if err := AttachController(r, &ControllerLayered{}, WithChainedWrappers()); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := AttachController(r, &ControllerLayeredPerRequest{ControllerAuthBase: &ControllerAuthBase{}}, WithChainedWrappers()); err != nil {
+		t.Error(err)
+		return
+	}
+
+	helperRunTestsForRouter(t, r, testControllerLayered1Values)
+}
+
+type ControllerDelegating struct {
+	ControllerAuthBase
+}
+
+// explicit delegation to the base wrapper
+func (c *ControllerDelegating) Before(ctx *gin.Context) {
+	c.ControllerAuthBase.Before(ctx)
+	ctx.Writer.WriteString("before ")
+}
+
+func (c *ControllerDelegating) Get(ctx *gin.Context) {
+	ctx.Writer.WriteString("get ")
+}
+
+var (
+	testControllerDelegating1Values = []*testCase{
+		// promoted After, own Before calls the base one once
+		{http.MethodGet, "/delegating/", http.StatusOK, "base-before before get base-after"},
+		// NOTE chained wrappers repeat explicitly delegated base Before
+		{http.MethodGet, "/chained/", http.StatusOK, "base-before base-before before get base-after"},
+	}
+)
+
+//
+// go test -count=1 -v -run TestRegisterControllerDelegating1
+
+func TestRegisterControllerDelegating1(t *testing.T) {
+
+	r := newRouter()
+
+	if err := AttachController(r, &ControllerDelegating{}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := AttachController(r, &ControllerDelegating{}, WithPrefix("chained"), WithChainedWrappers()); err != nil {
+		t.Error(err)
+		return
+	}
+
+	helperRunTestsForRouter(t, r, testControllerDelegating1Values)
+}
+
+//
+// ==============
+
 type ControllerTestInit struct {
 	t *testing.T
 }
@@ -478,3 +642,707 @@ func TestRegisterControllerWithWrappers1(t *testing.T) {
 		return
 	}
 }
+
+//
+// go test -count=1 -v -run TestRegisterControllerParams1
+
+var (
+	testControllerParams1Values = []*testCase{
+		{http.MethodGet, "/params/user/42", http.StatusOK, "ControllerParams.GET:UserByID 42"},
+		{http.MethodPut, "/params/user/42", http.StatusOK, "ControllerParams.PUT:UserBy_ID 42"},
+		{http.MethodGet, "/params/user-by-id", http.StatusNotFound, errStr404},
+		//
+		{http.MethodGet, "/params/post/1/comments/2", http.StatusOK, "ControllerParams.GET:PostByPostID_CommentsByCommentID 1 2"},
+		//
+		{http.MethodGet, "/params/files/a/b.txt", http.StatusOK, "ControllerParams.GET:FilesCatchAllPath /a/b.txt"},
+		{http.MethodGet, "/params/static/css/main.css", http.StatusOK, "ControllerParams.GET:StaticCatchAll /css/main.css"},
+		//
+		{http.MethodGet, "/params/bypass", http.StatusOK, "ControllerParams.GET:Bypass"},
+	}
+)
+
+func TestRegisterControllerParams1(t *testing.T) {
+	testControllerInternal1(t, &ControllerParams{t}, true, testControllerParams1Values)
+}
+
+// go test -count=1 -v -run TestDecodeEndpoint1
+
+func TestDecodeEndpoint1(t *testing.T) {
+
+	cases := []struct {
+		in, want string
+		fail     bool
+	}{
+		{"", "", false},
+		{"OnlyMethod", "only-method", false},
+		{"ByID", ":id", false},
+		{"UserByID", "user/:id", false},
+		{"UserBy_ID", "user/:id", false},
+		{"UserBy_UserID_Posts", "user/:user_id/posts", false},
+		{"NearBy", "near-by", false},
+		{"Bytes", "bytes", false},
+		{"FilesCatchAll", "files/*rest", false},
+		{"FilesCatchAll_Path", "files/*path", false},
+		{"UserBy_", "", true},
+		{"FilesCatchAllPath_Tail", "", true},
+	}
+
+	for _, c := range cases {
+
+		got, err := decodeEndpoint(c.in, strcase.KebabCase)
+
+		if (err != nil) != c.fail || got != c.want {
+			t.Errorf("decodeEndpoint(%q) mismatch: want < %q, fail %v >, got < %q, %v >", c.in, c.want, c.fail, got, err)
+		}
+	}
+}
+
+//
+// go test -count=1 -v -run TestRegisterControllerVerbs1
+
+var (
+	testControllerVerbs1Values = []*testCase{
+		{http.MethodPatch, "/verbs/user", http.StatusOK, "ControllerVerbs.PATCH:User"},
+		{http.MethodPut, "/verbs/user", http.StatusMethodNotAllowed, errStr405},
+		//
+		{"PURGE", "/verbs/cache", http.StatusOK, "ControllerVerbs.PURGE:Cache"},
+		{"PROPFIND", "/verbs/file", http.StatusOK, "ControllerVerbs.PROPFIND:File"},
+	}
+)
+
+func TestRegisterControllerVerbs1(t *testing.T) {
+
+	if err := RegisterHttpMethod("purge"); err == nil {
+		t.Error("RegisterHttpMethod accepts lowercase method")
+		return
+	}
+
+	t.Run("registered", func(t *testing.T) {
+
+		testRestoreHttpMethods(t)
+
+		if err := RegisterHttpMethod(append([]string{"PURGE"}, WebDAVHttpMethods[:]...)...); err != nil {
+			t.Error(err)
+			return
+		}
+
+		// longest prefix match
+		if m := httpMethod("PropfindFile", HttpMethods(), false); m != "PROPFIND" {
+			t.Errorf("httpMethod(%q) = %q, want %q", "PropfindFile", m, "PROPFIND")
+		}
+
+		testControllerInternal1(t, &ControllerVerbs{t}, true, testControllerVerbs1Values)
+	})
+
+	// global methods are restored for the rest tests
+	if methods := HttpMethods(); containsString(methods, "PURGE") || containsString(methods, "PROPFIND") {
+		t.Errorf("RegisterHttpMethod methods are not restored: %v", methods)
+	}
+}
+
+// testRestoreHttpMethods restores global http methods changed by RegisterHttpMethod at the end of the test
+func testRestoreHttpMethods(t *testing.T) {
+
+	httpMethodsMu.RLock()
+	saved := httpMethods
+	httpMethodsMu.RUnlock()
+
+	t.Cleanup(func() {
+		httpMethodsMu.Lock()
+		httpMethods = saved
+		httpMethodsMu.Unlock()
+	})
+}
+
+// ==============
+
+type ControllerStrict struct {
+	_ struct{} `ginext:"exclude=GetHelper|PostHelper"`
+}
+
+func (c *ControllerStrict) Get(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "get")
+}
+
+func (c *ControllerStrict) GetUser(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "user")
+}
+
+func (c *ControllerStrict) DeleteUser(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "delete")
+}
+
+func (c *ControllerStrict) Header(ctx *gin.Context) {}
+
+func (c *ControllerStrict) Getaway(ctx *gin.Context) {}
+
+func (c *ControllerStrict) Deleted(ctx *gin.Context) {}
+
+func (c *ControllerStrict) Actionable(ctx *gin.Context) {}
+
+func (c *ControllerStrict) GetHelper() string {
+	return "helper"
+}
+
+func (c *ControllerStrict) PostHelper() {}
+
+func (c *ControllerStrict) PutHelper() {}
+
+var (
+	testDecodeControllerMethod1Cases = []struct {
+		in     string
+		strict bool
+		m, e   string
+	}{
+		{"Header", false, http.MethodHead, "er"},
+		{"Header", true, "", ""},
+		{"Getaway", true, "", ""},
+		{"Deleted", true, "", ""},
+		{"GETUser", false, http.MethodGet, "user"},
+		{"GETUser", true, "", ""},
+		{"Get", true, http.MethodGet, ""},
+		{"GetUser", true, http.MethodGet, "user"},
+		{"Get2", true, http.MethodGet, "2"},
+		{"DeleteByID", true, http.MethodDelete, ":id"},
+		{"Actionable", false, methodActionNotch, "able"},
+		{"Actionable", true, "", ""},
+		{"ActionUser", true, methodActionNotch, "user"},
+	}
+
+	testControllerStrict1Values = []*testCase{
+		{http.MethodGet, "/strict/", http.StatusOK, "get"},
+		{http.MethodGet, "/strict/user", http.StatusOK, "user"},
+		{http.MethodDelete, "/strict/user", http.StatusOK, "delete"},
+		{http.MethodHead, "/strict/er", http.StatusNotFound, errStr404},
+		{http.MethodGet, "/strict/away", http.StatusNotFound, errStr404},
+		{http.MethodDelete, "/strict/d", http.StatusNotFound, errStr404},
+		{http.MethodGet, "/strict/able", http.StatusNotFound, errStr404},
+	}
+)
+
+//
+// go test -count=1 -v -run TestDecodeControllerMethod1
+
+func TestDecodeControllerMethod1(t *testing.T) {
+
+	methods := sortHttpMethods(rfcHttpMethods[:])
+
+	for _, c := range testDecodeControllerMethod1Cases {
+		o := &options{naming: KebabCase, strictVerbs: c.strict}
+
+		if ms, e, err := decodeControllerMethod(c.in, methods, o); err != nil || strings.Join(ms, " ") != c.m || e != c.e {
+			t.Errorf("decodeControllerMethod(%q, strict %v) mismatch: want %q %q, got %q %q (err %v)", c.in, c.strict, c.m, c.e, ms, e, err)
+		}
+	}
+}
+
+// ==============
+
+type ControllerAccount struct{}
+
+func (c *ControllerAccount) GetOrPostLogin(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "login "+ctx.Request.Method)
+}
+
+func (c *ControllerAccount) PutPatchProfile(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "profile "+ctx.Request.Method)
+}
+
+func (c *ControllerAccount) GetOrder(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "order")
+}
+
+type ControllerAccountDuplicate struct{}
+
+func (c *ControllerAccountDuplicate) GetOrGetLogin(ctx *gin.Context) {}
+
+var (
+	testDecodeMultiVerb1Cases = []struct {
+		in     string
+		concat bool
+		ms, e  string
+	}{
+		{"GetOrPostLogin", false, "GET POST", "login"},
+		{"GetOrPost", false, "GET POST", ""},
+		{"GetOrPostOrDeleteByID", false, "GET POST DELETE", ":id"},
+		{"GetOrder", false, "GET", "order"},
+		{"GetOr", false, "GET", "or"},
+		{"PutPatchProfile", false, "PUT", "patch-profile"},
+		{"PutPatchProfile", true, "PUT PATCH", "profile"},
+		{"PutPatchedProfile", true, "PUT", "patched-profile"},
+		{"GetOrPutPatch", true, "GET PUT PATCH", ""},
+	}
+
+	testControllerAccount1Values = []*testCase{
+		{http.MethodGet, "/account/login", http.StatusOK, "login GET"},
+		{http.MethodPost, "/account/login", http.StatusOK, "login POST"},
+		{http.MethodPut, "/account/login", http.StatusMethodNotAllowed, errStr405},
+		{http.MethodPut, "/account/profile", http.StatusOK, "profile PUT"},
+		{http.MethodPatch, "/account/profile", http.StatusOK, "profile PATCH"},
+		{http.MethodGet, "/account/order", http.StatusOK, "order"},
+	}
+)
+
+//
+// go test -count=1 -v -run TestDecodeMultiVerb1
+
+func TestDecodeMultiVerb1(t *testing.T) {
+
+	methods := sortHttpMethods(rfcHttpMethods[:])
+
+	for _, c := range testDecodeMultiVerb1Cases {
+
+		o := &options{naming: KebabCase, concatVerbs: c.concat}
+
+		if ms, e, err := decodeControllerMethod(c.in, methods, o); err != nil || strings.Join(ms, " ") != c.ms || e != c.e {
+			t.Errorf("decodeControllerMethod(%q, concat %v) mismatch: want %q %q, got %q %q (err %v)", c.in, c.concat, c.ms, c.e, ms, e, err)
+		}
+	}
+}
+
+//
+// go test -count=1 -v -run TestRegisterControllerMultiVerb1
+
+func TestRegisterControllerMultiVerb1(t *testing.T) {
+
+	r := newRouter()
+
+	if err := AttachController(r, &ControllerAccount{}, WithConcatVerbs()); err != nil {
+		t.Error(err)
+		return
+	}
+
+	helperRunTestsForRouter(t, r, testControllerAccount1Values)
+
+	if err := AttachController(newRouter(), &ControllerAccountDuplicate{}); err == nil {
+		t.Error("ControllerAccountDuplicate with duplicate verbs registered without error")
+	}
+}
+
+// ==============
+
+type ControllerRestricted struct{}
+
+func (c *ControllerRestricted) ActionMethods() []string {
+	return []string{http.MethodGet, http.MethodPost}
+}
+
+func (c *ControllerRestricted) ActionForm(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "form "+ctx.Request.Method)
+}
+
+type ControllerActionOption struct{}
+
+func (c *ControllerActionOption) ActionForm(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "form "+ctx.Request.Method)
+}
+
+type ControllerActionMethodsEmpty struct{}
+
+func (c *ControllerActionMethodsEmpty) ActionMethods() []string {
+	return nil
+}
+
+func (c *ControllerActionMethodsEmpty) ActionForm(ctx *gin.Context) {}
+
+type ControllerActionMethodsBad struct{}
+
+func (c *ControllerActionMethodsBad) ActionMethods() string {
+	return http.MethodGet
+}
+
+func (c *ControllerActionMethodsBad) ActionForm(ctx *gin.Context) {}
+
+var (
+	testControllerRestricted1Values = []*testCase{
+		{http.MethodGet, "/restricted/form", http.StatusOK, "form GET"},
+		{http.MethodPost, "/restricted/form", http.StatusOK, "form POST"},
+		{http.MethodTrace, "/restricted/form", http.StatusMethodNotAllowed, errStr405},
+		{http.MethodConnect, "/restricted/form", http.StatusMethodNotAllowed, errStr405},
+		{http.MethodGet, "/restricted/methods", http.StatusNotFound, errStr404},
+		// controller method takes precedence over option
+		{http.MethodPut, "/restricted/form", http.StatusMethodNotAllowed, errStr405},
+		//
+		{http.MethodPut, "/action-option/form", http.StatusOK, "form PUT"},
+		{http.MethodDelete, "/action-option/form", http.StatusOK, "form DELETE"},
+		{http.MethodTrace, "/action-option/form", http.StatusMethodNotAllowed, errStr405},
+	}
+)
+
+//
+// go test -count=1 -v -run TestRegisterControllerActionMethods1
+
+func TestRegisterControllerActionMethods1(t *testing.T) {
+
+	r := newRouter()
+
+	if err := AttachController(r, &ControllerRestricted{}, WithActionMethods(http.MethodPut)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := AttachController(r, &ControllerActionOption{}, WithActionMethods(http.MethodPut, http.MethodDelete)); err != nil {
+		t.Error(err)
+		return
+	}
+
+	helperRunTestsForRouter(t, r, testControllerRestricted1Values)
+
+	for _, c := range []struct {
+		instance interface{}
+		opts     []Option
+	}{
+		{&ControllerActionMethodsEmpty{}, nil},
+		{&ControllerActionMethodsBad{}, nil},
+		{&ControllerActionOption{}, []Option{WithActionMethods()}},
+		{&ControllerActionOption{}, []Option{WithActionMethods("get")}},
+	} {
+		if err := AttachController(newRouter(), c.instance, c.opts...); err == nil {
+			t.Errorf("controller %T with bad action methods registered without error", c.instance)
+		}
+	}
+}
+
+//
+// go test -count=1 -v -run TestRegisterControllerStrict1
+
+func TestRegisterControllerStrict1(t *testing.T) {
+
+	// non-strict mode registers helpers with wrong signatures
+	if err := AttachController(newRouter(), &ControllerStrict{}); err == nil {
+		t.Error("ControllerStrict registered without error in non-strict mode")
+	}
+
+	r := newRouter()
+
+	// GetHelper and PostHelper are excluded by tag, PutHelper by option
+	if err := AttachController(r, &ControllerStrict{}, WithStrictVerbs(true), WithExclude("PutHelper")); err != nil {
+		t.Error(err)
+		return
+	}
+
+	helperRunTestsForRouter(t, r, testControllerStrict1Values)
+}
+
+// ==============
+
+type ControllerTestActionHooks struct {
+	t *testing.T
+	a []string
+}
+
+func (c *ControllerTestActionHooks) Before(ctx *gin.Context) {
+	c.a = append(c.a, "before")
+}
+
+func (c *ControllerTestActionHooks) BeforeAction(ctx *gin.Context, action string) {
+	c.a = append(c.a, "before:"+action)
+}
+
+func (c *ControllerTestActionHooks) BeforePostSaveData(ctx *gin.Context) {
+
+	c.a = append(c.a, "before-post-save-data")
+
+	if ctx.Query("deny") != "" {
+		ctx.String(http.StatusForbidden, "ControllerTestActionHooks.BeforePostSaveData")
+		ctx.Abort()
+	}
+}
+
+func (c *ControllerTestActionHooks) PostSaveData(ctx *gin.Context) {
+	c.a = append(c.a, "handler")
+	ctx.String(http.StatusOK, "ControllerTestActionHooks.POST:SaveData")
+}
+
+func (c *ControllerTestActionHooks) GetData(ctx *gin.Context) {
+	c.a = append(c.a, "handler")
+	ctx.String(http.StatusOK, "ControllerTestActionHooks.GET:Data")
+}
+
+func (c *ControllerTestActionHooks) AfterPostSaveData(ctx *gin.Context) {
+	c.a = append(c.a, "after-post-save-data")
+}
+
+func (c *ControllerTestActionHooks) AfterAction(ctx *gin.Context, action string) {
+	c.a = append(c.a, "after:"+action)
+}
+
+func (c *ControllerTestActionHooks) After(ctx *gin.Context) {
+	c.a = append(c.a, "after")
+}
+
+type ControllerTestBadActionHook struct {
+	t *testing.T
+}
+
+func (c *ControllerTestBadActionHook) BeforeGetData(ctx *gin.Context, action string) {}
+
+func (c *ControllerTestBadActionHook) GetData(ctx *gin.Context) {}
+
+// go test -count=1 -v -run TestRegisterControllerWithActionHooks1
+
+func TestRegisterControllerWithActionHooks1(t *testing.T) {
+
+	c := &ControllerTestActionHooks{t, nil}
+
+	seqTests := []struct {
+		tcase   *testCase
+		wantSeq []string
+	}{
+		{
+			&testCase{http.MethodPost, "/test-action-hooks/save-data", http.StatusOK, "ControllerTestActionHooks.POST:SaveData"},
+			[]string{"before", "before:PostSaveData", "before-post-save-data", "handler", "after-post-save-data", "after:PostSaveData", "after"},
+		},
+		{
+			&testCase{http.MethodPost, "/test-action-hooks/save-data?deny=1", http.StatusForbidden, "ControllerTestActionHooks.BeforePostSaveData"},
+			[]string{"before", "before:PostSaveData", "before-post-save-data"},
+		},
+		{
+			&testCase{http.MethodGet, "/test-action-hooks/data", http.StatusOK, "ControllerTestActionHooks.GET:Data"},
+			[]string{"before", "before:GetData", "handler", "after:GetData", "after"},
+		},
+	}
+
+	r := newRouter()
+
+	if err := registerController(r, c, true); err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, st := range seqTests {
+
+		c.a = nil
+
+		helperRunTestsForRouter(t, r, []*testCase{st.tcase})
+
+		if !reflect.DeepEqual(c.a, st.wantSeq) {
+			t.Errorf("ControllerTestActionHooks erroneous handlers seq: got %v, want %v", c.a, st.wantSeq)
+			return
+		}
+	}
+
+	if err := registerController(newRouter(), &ControllerTestBadActionHook{t}, true); err == nil {
+		t.Error("ControllerTestBadActionHook registered without error")
+	}
+}
+
+// ==============
+
+type ControllerTestAround struct {
+	t *testing.T
+	a []string
+}
+
+func (c *ControllerTestAround) Before(ctx *gin.Context) {
+	c.a = append(c.a, "before")
+}
+
+func (c *ControllerTestAround) Around(ctx *gin.Context, next func()) {
+
+	c.a = append(c.a, "around:start")
+
+	defer func() {
+
+		if rec := recover(); rec != nil {
+			c.a = append(c.a, "around:recover")
+			ctx.String(http.StatusInternalServerError, "ControllerTestAround.Around recovered")
+			ctx.Abort()
+		}
+
+		c.a = append(c.a, "around:end")
+	}()
+
+	next()
+}
+
+func (c *ControllerTestAround) BeforeGetOk(ctx *gin.Context) {
+	c.a = append(c.a, "before-get-ok")
+}
+
+func (c *ControllerTestAround) GetOk(ctx *gin.Context) {
+	c.a = append(c.a, "handler")
+	ctx.String(http.StatusOK, "ControllerTestAround.GET:Ok")
+}
+
+func (c *ControllerTestAround) GetPanic(ctx *gin.Context) {
+	c.a = append(c.a, "handler")
+	panic("ControllerTestAround.GetPanic")
+}
+
+func (c *ControllerTestAround) After(ctx *gin.Context) {
+	c.a = append(c.a, "after")
+}
+
+// go test -count=1 -v -run TestRegisterControllerWithAround1
+
+func TestRegisterControllerWithAround1(t *testing.T) {
+
+	c := &ControllerTestAround{t, nil}
+
+	seqTests := []struct {
+		tcase   *testCase
+		wantSeq []string
+	}{
+		{
+			&testCase{http.MethodGet, "/test-around/ok", http.StatusOK, "ControllerTestAround.GET:Ok"},
+			[]string{"before", "around:start", "before-get-ok", "handler", "around:end", "after"},
+		},
+		{
+			&testCase{http.MethodGet, "/test-around/panic", http.StatusInternalServerError, "ControllerTestAround.Around recovered"},
+			[]string{"before", "around:start", "handler", "around:recover", "around:end"},
+		},
+	}
+
+	r := newRouter()
+
+	if err := registerController(r, c, true); err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, st := range seqTests {
+
+		c.a = nil
+
+		helperRunTestsForRouter(t, r, []*testCase{st.tcase})
+
+		if !reflect.DeepEqual(c.a, st.wantSeq) {
+			t.Errorf("ControllerTestAround erroneous handlers seq: got %v, want %v", c.a, st.wantSeq)
+			return
+		}
+	}
+}
+
+// ==============
+
+type ControllerTestFinally struct {
+	t *testing.T
+	a []string
+}
+
+func (c *ControllerTestFinally) Finally(ctx *gin.Context) {
+	c.a = append(c.a, "finally")
+}
+
+func (c *ControllerTestFinally) Before(ctx *gin.Context) {
+
+	c.a = append(c.a, "before")
+
+	if ctx.Query("deny") != "" {
+		ctx.String(http.StatusForbidden, "ControllerTestFinally.Before")
+		ctx.Abort()
+	}
+}
+
+func (c *ControllerTestFinally) Get(ctx *gin.Context) {
+	c.a = append(c.a, "handler")
+	ctx.String(http.StatusOK, "ControllerTestFinally.GET [index]")
+}
+
+func (c *ControllerTestFinally) GetPanic(ctx *gin.Context) {
+	c.a = append(c.a, "handler")
+	panic("ControllerTestFinally.GetPanic")
+}
+
+func (c *ControllerTestFinally) After(ctx *gin.Context) {
+	c.a = append(c.a, "after")
+}
+
+// go test -count=1 -v -run TestRegisterControllerWithFinally1
+
+func TestRegisterControllerWithFinally1(t *testing.T) {
+
+	c := &ControllerTestFinally{t, nil}
+
+	seqTests := []struct {
+		tcase   *testCase
+		wantSeq []string
+	}{
+		{
+			&testCase{http.MethodGet, "/test-finally/", http.StatusOK, "ControllerTestFinally.GET [index]"},
+			[]string{"before", "handler", "after", "finally"},
+		},
+		{
+			&testCase{http.MethodGet, "/test-finally/?deny=1", http.StatusForbidden, "ControllerTestFinally.Before"},
+			[]string{"before", "finally"},
+		},
+		{
+			&testCase{http.MethodGet, "/test-finally/panic", http.StatusInternalServerError, ""},
+			[]string{"before", "handler", "finally"},
+		},
+	}
+
+	r := newRouter()
+
+	r.Use(gin.CustomRecoveryWithWriter(ioutil.Discard, func(ctx *gin.Context, err any) {
+		ctx.AbortWithStatus(http.StatusInternalServerError)
+	}))
+
+	if err := registerController(r, c, true); err != nil {
+		t.Error(err)
+		return
+	}
+
+	for _, st := range seqTests {
+
+		c.a = nil
+
+		helperRunTestsForRouter(t, r, []*testCase{st.tcase})
+
+		if !reflect.DeepEqual(c.a, st.wantSeq) {
+			t.Errorf("ControllerTestFinally erroneous handlers seq: got %v, want %v", c.a, st.wantSeq)
+			return
+		}
+	}
+}
+
+// ==============
+
+type ControllerTestInitAbsPath struct {
+	t       *testing.T
+	absPath string
+}
+
+func (c *ControllerTestInitAbsPath) Init(absPath string) error {
+	c.absPath = absPath
+	return nil
+}
+
+func (c *ControllerTestInitAbsPath) Get(ctx *gin.Context) {
+	ctx.String(http.StatusOK, c.absPath)
+}
+
+// go test -count=1 -v -run TestRegisterControllerInitAbsPath1
+
+func TestRegisterControllerInitAbsPath1(t *testing.T) {
+
+	cases := []struct {
+		rg      RouterGroup
+		prepend bool
+		opts    []Option
+		want    string
+	}{
+		{newRouter(), true, nil, "/test-init-abs-path"},
+		{newRouter(), false, nil, "/"},
+		{newRouter().Group("/api/v1"), true, nil, "/api/v1/test-init-abs-path"},
+		{newRouter().Group("/api/v1"), false, nil, "/api/v1"},
+		{newRouter().Group("/api"), true, []Option{WithPrefix("/custom/")}, "/api/custom"},
+		// plain gin.IRoutes without BasePath
+		{struct{ gin.IRoutes }{newRouter().Group("/api")}, true, nil, "/test-init-abs-path"},
+	}
+
+	for _, c := range cases {
+
+		ci := &ControllerTestInitAbsPath{t, ""}
+
+		if err := registerController(c.rg, ci, c.prepend, c.opts...); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if ci.absPath != c.want {
+			t.Errorf("ControllerTestInitAbsPath.Init absPath mismatch: want %q, got %q", c.want, ci.absPath)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+type (
+	// Route overrides or extends the route derived from the controller method name SEE ControllerRoutesMethod
+	Route struct {
+		// http method or "ANY" for the same methods as `Action` methods (SEE WithActionMethods), empty keeps derived one
+		Method string
+		// path relative to the controller endpoint or, if it starts with slash, to the router group (e.g. root-level
+		// legacy URL "/getUserInfo.php"), which is used as is (without trailing slash appending), empty keeps derived one
+		Path string
+		// route middleware, which runs after Before wrapper right before action scoped chain (and Around wrapper)
+		Middleware []gin.HandlerFunc
+	}
+
+	// ControllerRoutesMethod is the signature of `Routes` controller method, which returns route overrides
+	// by the controller method names; method, which is not the action one by name, becomes action with its Route
+	ControllerRoutesMethod = func() map[string]Route
+)
+
+const routesMethod = "Routes"
+
+// extractRoutesMethod returns route overrides of the controller, all of them are referencing existing methods
+func extractRoutesMethod(instance interface{}, v *reflect.Value) (map[string]Route, error) {
+
+	methodValue := v.MethodByName(routesMethod)
+
+	// SEE https://github.com/golang/go/issues/46320#issuecomment-1081940201
+	if !methodValue.IsValid() || methodValue.IsNil() {
+		return nil, nil
+	}
+
+	routesFunc, ok := methodValue.Interface().(ControllerRoutesMethod)
+
+	if !ok {
+		return nil, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has %s method with wrong signature %T", instance, routesMethod, methodValue.Interface())
+	}
+
+	routes := routesFunc()
+
+	names := make([]string, 0, len(routes))
+
+	for name := range routes {
+		names = append(names, name)
+	}
+
+	// stable error
+	sort.Strings(names)
+
+	t := v.Type()
+
+	for _, name := range names {
+
+		if _, ok := t.MethodByName(name); !ok || name == routesMethod {
+			return nil, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has route override of unknown method %s", instance, name)
+		}
+
+		if m := routes[name].Method; m != "" && m != routeMethodAny && !isValidHttpMethod(m) {
+			return nil, fmt.Errorf(errRegisterControllerPrefix+"controller instance %v of type %[1]T has route override of method %s with wrong http method %q", instance, name, m)
+		}
+	}
+
+	return routes, nil
+}
+
+// override returns http method and endpoint of the route derived from the method name (m is empty for non-action method)
+// replaced by Route ones
+func (r *Route) override(method, m, e string, naming NamingStrategy) (string, string, error) {
+
+	if m == "" {
+
+		if r.Method == "" {
+			return "", "", fmt.Errorf("route override of non-action method %s has no http method", method)
+		}
+
+		if r.Path == "" {
+
+			var err error
+
+			if e, err = decodeEndpoint(method, naming); err != nil {
+				return "", "", err
+			}
+		}
+	}
+
+	switch r.Method {
+	case "":
+	case routeMethodAny:
+		m = methodActionNotch
+	default:
+		m = r.Method
+	}
+
+	// NOTE leading slash is kept to skip the controller endpoint SEE Route.Path
+	if r.Path != "" {
+		e = r.Path
+	}
+
+	return m, e, nil
+}
@@ -0,0 +1,136 @@
+/**
+ * This file is part of the go ginext package (https://github.com/Illirgway/go-ginext)
+ *
+ * Copyright (c) 2023 Illirgway
+ *
+ * This program is free software: you can redistribute it and/or modify it under the terms of the GNU
+ * General Public License as published by the Free Software Foundation, either version 3 of the License,
+ * or (at your option) any later version.
+ *
+ * This program is distributed in the hope that it will be useful, but WITHOUT ANY WARRANTY;
+ * without even the implied warranty of MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.
+ * See the GNU General Public License for more details.
+ *
+ * You should have received a copy of the GNU General Public License along with this program.
+ * If not, see <https://www.gnu.org/licenses/>.
+ *
+ */
+
+package ginext
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"testing"
+)
+
+type ControllerLegacy struct{}
+
+func (c *ControllerLegacy) Routes() map[string]Route {
+	return map[string]Route{
+		"GetUserInfo": {Path: "/getUserInfo.php"},
+		"GetProfile":  {Path: "profile.php"},
+		"PostItem":    {Method: http.MethodPut},
+		"Health":      {Method: http.MethodGet, Middleware: []gin.HandlerFunc{testLegacyMiddleware}},
+		"Users":       {Method: "ANY", Path: "v2/users"},
+	}
+}
+
+func testLegacyMiddleware(ctx *gin.Context) {
+	ctx.Writer.WriteString("mw ")
+}
+
+func (c *ControllerLegacy) GetUserInfo(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "user info")
+}
+
+func (c *ControllerLegacy) GetProfile(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "profile")
+}
+
+func (c *ControllerLegacy) PostItem(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "item")
+}
+
+func (c *ControllerLegacy) Health(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "health")
+}
+
+func (c *ControllerLegacy) Users(ctx *gin.Context) {
+	ctx.String(http.StatusOK, "users "+ctx.Request.Method)
+}
+
+type ControllerRoutesUnknown struct{}
+
+func (c *ControllerRoutesUnknown) Routes() map[string]Route {
+	return map[string]Route{"GetMissing": {Path: "missing"}}
+}
+
+func (c *ControllerRoutesUnknown) Get(ctx *gin.Context) {}
+
+type ControllerRoutesBadMethod struct{}
+
+func (c *ControllerRoutesBadMethod) Routes() map[string]Route {
+	return map[string]Route{"Get": {Method: "get"}}
+}
+
+func (c *ControllerRoutesBadMethod) Get(ctx *gin.Context) {}
+
+type ControllerRoutesNoMethod struct{}
+
+func (c *ControllerRoutesNoMethod) Routes() map[string]Route {
+	return map[string]Route{"Health": {Path: "health"}}
+}
+
+func (c *ControllerRoutesNoMethod) Health(ctx *gin.Context) {}
+
+type ControllerRoutesBadSignature struct{}
+
+func (c *ControllerRoutesBadSignature) Routes() []Route {
+	return nil
+}
+
+func (c *ControllerRoutesBadSignature) Get(ctx *gin.Context) {}
+
+var (
+	testControllerLegacy1Values = []*testCase{
+		// leading slash path is relative to the router group
+		{http.MethodGet, "/getUserInfo.php", http.StatusOK, "user info"},
+		{http.MethodGet, "/legacy/getUserInfo.php", http.StatusNotFound, errStr404},
+		{http.MethodGet, "/legacy/user-info", http.StatusNotFound, errStr404},
+		{http.MethodGet, "/legacy/profile.php", http.StatusOK, "profile"},
+		{http.MethodGet, "/api/getUserInfo.php", http.StatusOK, "user info"},
+		{http.MethodGet, "/api/legacy/profile.php", http.StatusOK, "profile"},
+		{http.MethodPut, "/legacy/item", http.StatusOK, "item"},
+		{http.MethodGet, "/legacy/health", http.StatusOK, "mw health"},
+		{http.MethodGet, "/legacy/v2/users", http.StatusOK, "users GET"},
+		{http.MethodDelete, "/legacy/v2/users", http.StatusOK, "users DELETE"},
+		{http.MethodGet, "/legacy/routes", http.StatusNotFound, errStr404},
+	}
+)
+
+//
+// go test -count=1 -v -run TestRegisterControllerRoutes1
+
+func TestRegisterControllerRoutes1(t *testing.T) {
+
+	r := newRouter()
+
+	if err := AttachController(r, &ControllerLegacy{}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	if err := AttachController(r.Group("/api"), &ControllerLegacy{}); err != nil {
+		t.Error(err)
+		return
+	}
+
+	helperRunTestsForRouter(t, r, testControllerLegacy1Values)
+
+	for _, c := range []interface{}{&ControllerRoutesUnknown{}, &ControllerRoutesBadMethod{}, &ControllerRoutesNoMethod{}, &ControllerRoutesBadSignature{}} {
+		if err := AttachController(newRouter(), c); err == nil {
+			t.Errorf("controller %T with bad route overrides registered without error", c)
+		}
+	}
+}